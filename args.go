@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"net"
+	"path"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -10,10 +13,17 @@ import (
 type Verb string
 
 const (
-	VerbGet      Verb = "get"
-	VerbDelete   Verb = "delete"
-	VerbDescribe Verb = "describe"
-	VerbTop      Verb = "top"
+	VerbGet            Verb = "get"
+	VerbDelete         Verb = "delete"
+	VerbDescribe       Verb = "describe"
+	VerbTop            Verb = "top"
+	VerbLogs           Verb = "logs"
+	VerbScale          Verb = "scale"
+	VerbRolloutRestart Verb = "rollout-restart"
+	VerbLabel          Verb = "label"
+	VerbAnnotate       Verb = "annotate"
+	VerbPatch          Verb = "patch"
+	VerbWait           Verb = "wait"
 )
 
 type MatchMode int
@@ -23,6 +33,7 @@ const (
 	MatchRegex
 	MatchContains
 	MatchFuzzy
+	MatchExact
 )
 
 type CLIOptions struct {
@@ -32,48 +43,195 @@ type CLIOptions struct {
 	Exclude    []string
 	Mode       MatchMode
 	IgnoreCase bool
+	Invert     bool // keep names NOT matching the patterns (excludes still apply)
 	BatchSize  int
 	Yes        bool
 	DryRun     bool
 	NoColor    bool
 	Preview    string // "list" (default) or "table"
+	// Target replica count for scale (-1 = not given)
+	Replicas int
+	// key=value (or key- to remove) arguments for label/annotate
+	Mutations []string
+	// Max names printed by the list preview (0 = all); the confirm still covers every match
+	PreviewLimit int
+	// Match patterns against namespace/name even without -A (e.g., 'prod/web-*')
+	FullNameMatch bool
 	// Namespace filters (applied after discovery)
 	NsExact  []string
 	NsPrefix []string
 	NsRegex  []string
+	// Namespaces dropped even when an include filter matched (forces -A)
+	NsExclude       []string
+	NsExcludePrefix []string
+	// Run once per kubeconfig file, with KUBECONFIG set for every kubectl call
+	Kubeconfigs []string
 	// Safety
 	ConfirmThreshold int
 	ServerDryRun     bool
+	EmitRevert       string // file to write a restorable List manifest of deleted objects
+	RemoveFinalizers bool   // patch metadata.finalizers to null before deleting
+	RetryConflict    int    // retries for mutating calls failing with 409 Conflict
+	ContinueOnError  bool   // keep going past failed batches and summarize at the end
+	MaxParallel      int    // namespaces processed concurrently by non-get verbs under -A
 	Fuzzy            bool
 	FuzzyMaxDistance int
 	OlderThan        time.Duration
 	YoungerThan      time.Duration
-	PodStatuses      []string
+	AsOf             time.Time // age filters are evaluated relative to this time (zero: now)
+	PodStatuses      []string  // --status/--pod-status: phase (any resource) or pod container reason
 	Unhealthy bool
 	Debug     bool
+	// Print per-stage filter attrition to stderr
+	Selectivity bool
 
 	// Label filtering and grouping
 	LabelFilters   []LabelFilter
-	GroupByLabel   string
+	GroupByLabel   []string // label keys; several form composite groups
 	ColorizeLabels bool
+	// Summarize matches by ownerReferences Kind/Name on stderr
+	GroupByOwner bool
 
 	// Label key presence by regex
 	LabelKeyRegex []string
+	// Exact label keys objects must have (any value) / must not have
+	HasLabel []string
+	NoLabel  []string
 
 	// Annotation filtering
 	AnnotationFilters  []LabelFilter
 	AnnotationKeyRegex []string
+	HasAnnotation      []string
+	NoAnnotation       []string
+
+	// Finalizer filters (any resource)
+	HasFinalizers bool
+	Finalizers    []string // keep objects carrying any of these finalizers
+	// With -A: keep names that appear in more than one namespace
+	NameCollisions bool
+	// Drop items without metadata.namespace (malformed items in a namespaced list)
+	RequireNamespace bool
+	// With -A get: append a CONTROLLED-BY column built from ownerReferences
+	ShowOwner bool
+	// With get pods -A: build the wide table client-side from discovery
+	ClientTable bool
+	// Order matches (and --client-table rows) by age|restarts|ready|name|node|namespace
+	SortBy      string
+	SortReverse bool
+	// Keep only the first Limit matches (after --sort-by, else in discovery order)
+	Limit int
+	// Objects with metadata.deletionTimestamp set (any resource)
+	Terminating bool
+	// ReplicaSets scaled to zero in spec and status (old Deployment revisions)
+	StaleReplicaSet bool
+	// Deployments/StatefulSets/ReplicaSets with fewer ready replicas than desired
+	ReplicasUnready bool
+	// Field manager globs matched against metadata.managedFields (any of)
+	ManagedBy []string
+	// Owner reference filters (any resource): kind and name glob
+	OwnerKinds []string
+	OwnerNames []string
+	// status.conditions filters TYPE=STATUS (any resource, AND across filters)
+	Conditions []LabelFilter
+	// Node condition shortcuts (nodes only): Ready!=True, and any of the listed pressure conditions True
+	NodeNotReady bool
+	NodePressure []string
 
 	// Node filters
 	NodeExact  []string
 	NodePrefix []string
 	NodeRegex  []string
+	// Pod spec.nodeSelector filters (AND across filters)
+	NodeSelectorFilters []LabelFilter
+	NoNodeSelector      bool
+	// Pod spec.affinity presence filters
+	HasAffinity bool
+	NoAffinity  bool
+	// Taint keys a pod must tolerate (AND across keys)
+	Tolerates []string
+	// Pod spec.restartPolicy (Always, OnFailure, Never)
+	RestartPolicy string
+	// Pod status.qosClass values (any of): Guaranteed, Burstable, BestEffort
+	QOSClasses []string
+	// Pod status.podIP / status.hostIP filters: CIDRs or globs (any of)
+	PodIPs  []string
+	HostIPs []string
+	// Pods with a container lacking a readiness/liveness probe
+	NoReadinessProbe bool
+	NoLivenessProbe  bool
+	// Pods whose spec.terminationGracePeriodSeconds exceeds this (0: no filter)
+	GracePeriodLongerThan int64
+	// PVC claim name globs; keep pods mounting any of them
+	UsesPVC []string
+	// ConfigMap/Secret name globs; keep pods referencing any of them
+	UsesConfigMap []string
+	UsesSecret    []string
+	// Container image globs/regexes; keep pods with any container image matching
+	Image      []string
+	ImageRegex []string
 
 	// Pod container health
 	RestartExpr        string // e.g., ">3", "<=1"
 	ContainersNotReady bool
 	ReasonFilters      []string
 	ContainerScope     string // container name to scope reason/restart checks
+	// Compared against the number of ready app containers, e.g. "<2"
+	ReadyContainersExpr string
+	// Inclusive StatefulSet ordinal range (pods), e.g. 1-3
+	OrdinalRangeSet bool
+	OrdinalMin      int
+	OrdinalMax      int
+
+	// Churning: old pods that keep restarting recently
+	Churning         bool
+	ChurningAge      time.Duration // pod must be older than this; restarts must fall within it
+	ChurningRestarts int
+
+	// Pods whose Ready condition changed within this window
+	ReadyFlappedWithin time.Duration
+
+	// Restart delta against a saved `kubectl get pods -o json` snapshot
+	RestartDelta int
+	FromSnapshot string
+
+	// Scheduling
+	Unscheduled     bool // Pending pods with no node assigned
+	SchedulingGated bool // pods held back by spec.schedulingGates
+
+	// Structured output instead of running kubectl
+	Metrics bool // Prometheus textfile metrics
+	JSON    bool // {"wildVersion":"1","items":[...]}
+	Bare    bool // omit the format version wrapper/header
+	// Grouped counts by namespace|node|phase|label:KEY
+	CountBy string
+	// Events about the matched objects instead of the objects themselves
+	Events bool
+	// ns/name, phase and restarts per match, joined by OutputSeparator
+	NamesStatus     bool
+	OutputSeparator string
+	// Names only (namespace/name under -A), newline- or NUL-separated
+	NamesOnly bool
+	Print0    bool
+	// Strip "Managed Fields" blocks from describe output
+	CompactDescribe bool
+	// Page get/describe output through $PAGER when stdout is a TTY
+	Pager   bool
+	NoPager bool
+
+	// Exit non-zero (errNoMatches) when nothing matched
+	ErrorOnEmpty bool
+	// "Did you mean" on zero matches: forced on by --suggest, off by --no-suggest
+	// (by default only for a literal glob/exact pattern)
+	Suggest   bool
+	NoSuggest bool
+	// Randomly keep Sample of the matches before the verb runs; SeedSet makes it reproducible
+	Sample  int
+	Seed    int64
+	SeedSet bool
+
+	// Polling: re-run discovery+filters until PollUntilCount items match or PollTimeout elapses
+	PollTimeout    time.Duration
+	PollUntilCount int
 
 	// Raw flags for discovery `kubectl get ... -o json`
 	DiscoveryFlags []string
@@ -90,8 +248,13 @@ type CLIOptions struct {
 
 func defaultCLIOptions() CLIOptions {
 	return CLIOptions{
-		Mode:      MatchGlob,
-		BatchSize: 200,
+		Mode:             MatchGlob,
+		BatchSize:        200,
+		PreviewLimit:     50,
+		ChurningAge:      24 * time.Hour,
+		ChurningRestarts: 5,
+		Replicas:         -1,
+		MaxParallel:      1,
 	}
 }
 
@@ -107,10 +270,14 @@ func parseArgs(argv []string) (CLIOptions, error) {
 	opts := defaultCLIOptions()
 	opts.Verb = Verb(argv[0])
 	switch opts.Verb {
-	case VerbGet, VerbDelete, VerbDescribe, VerbTop:
+	case VerbGet, VerbDelete, VerbDescribe, VerbTop, VerbLogs, VerbScale, VerbRolloutRestart, VerbLabel, VerbAnnotate, VerbPatch, VerbWait:
 	default:
 		return opts, fmt.Errorf("unknown verb: %s", argv[0])
 	}
+	// logs always targets pods; allow `logs 'api-*'` as well as `logs pods 'api-*'`
+	if opts.Verb == VerbLogs && len(argv) > 1 && !strings.HasPrefix(argv[1], "-") && !isPodsResource(argv[1]) {
+		argv = append([]string{argv[0], "pods"}, argv[1:]...)
+	}
 
 	// split on -- to collect ExtraFinal flags
 	var head []string
@@ -138,7 +305,8 @@ func parseArgs(argv []string) (CLIOptions, error) {
 		idxAfterRes = 2
 	}
 	// Pattern present?
-	if len(head) > idxAfterRes && !strings.HasPrefix(head[idxAfterRes], "-") {
+	if len(head) > idxAfterRes && !strings.HasPrefix(head[idxAfterRes], "-") &&
+		!(isMetadataVerb(opts.Verb) && isMetadataMutation(head[idxAfterRes])) {
 		opts.Include = append(opts.Include, head[idxAfterRes])
 		includeWasDefault = false
 		// flags start after pattern
@@ -157,6 +325,10 @@ func parseArgs(argv []string) (CLIOptions, error) {
 		flagsStart = len(head)
 	}
 	flags := head[flagsStart:]
+	// --color mode; "" (auto) leaves color to colorByDefault
+	colorMode := ""
+	// which --poll-until-* flags were given; PollUntilCount 0 alone can't tell them apart
+	pollUntilEmpty, pollUntilCount, pollTimeout := false, false, false
 
 	// process flags, splitting plugin vs passthrough
 	for i := 0; i < len(flags); i++ {
@@ -166,12 +338,18 @@ func parseArgs(argv []string) (CLIOptions, error) {
 		case "--debug":
 			opts.Debug = true
 			continue
+		case "--selectivity":
+			opts.Selectivity = true
+			continue
 		case "--regex":
 			opts.Mode = MatchRegex
 			continue
 		case "--contains":
 			opts.Mode = MatchContains
 			continue
+		case "--exact":
+			opts.Mode = MatchExact
+			continue
 		case "--fuzzy":
 			opts.Mode = MatchFuzzy
 			opts.Fuzzy = true
@@ -209,8 +387,16 @@ func parseArgs(argv []string) (CLIOptions, error) {
 		case "--ignore-case":
 			opts.IgnoreCase = true
 			continue
+		case "--invert", "-v":
+			// Like grep -v. Note: this shadows kubectl's "-v N" verbosity; use -v=N for that.
+			opts.Invert = true
+			continue
+		case "--full-name-match":
+			opts.FullNameMatch = true
+			continue
 		case "--no-color":
 			opts.NoColor = true
+			colorMode = "never"
 			continue
 		case "--preview":
 			if i+1 >= len(flags) {
@@ -219,12 +405,34 @@ func parseArgs(argv []string) (CLIOptions, error) {
 			opts.Preview = flags[i+1]
 			i++
 			continue
+		case "--preview-limit":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--preview-limit requires a value")
+			}
+			n, err := strconv.Atoi(flags[i+1])
+			if err != nil || n < 0 {
+				return opts, fmt.Errorf("--preview-limit must be a non-negative integer")
+			}
+			opts.PreviewLimit = n
+			i++
+			continue
 		case "--yes", "-y":
 			opts.Yes = true
 			continue
 		case "--dry-run":
 			opts.DryRun = true
 			continue
+		case "--replicas":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--replicas requires a value")
+			}
+			n, err := strconv.Atoi(flags[i+1])
+			if err != nil || n < 0 {
+				return opts, fmt.Errorf("--replicas must be a non-negative integer")
+			}
+			opts.Replicas = n
+			i++
+			continue
 		case "--match":
 			if i+1 >= len(flags) {
 				return opts, fmt.Errorf("--match requires a value")
@@ -254,14 +462,14 @@ func parseArgs(argv []string) (CLIOptions, error) {
 			if i+1 >= len(flags) {
 				return opts, fmt.Errorf("--ns requires a value")
 			}
-			opts.NsExact = append(opts.NsExact, flags[i+1])
+			opts.NsExact = append(opts.NsExact, splitCommaList(flags[i+1])...)
 			i++
 			continue
 		case "--ns-prefix":
 			if i+1 >= len(flags) {
 				return opts, fmt.Errorf("--ns-prefix requires a value")
 			}
-			opts.NsPrefix = append(opts.NsPrefix, flags[i+1])
+			opts.NsPrefix = append(opts.NsPrefix, splitCommaList(flags[i+1])...)
 			i++
 			continue
 		case "--ns-regex":
@@ -271,6 +479,27 @@ func parseArgs(argv []string) (CLIOptions, error) {
 			opts.NsRegex = append(opts.NsRegex, flags[i+1])
 			i++
 			continue
+		case "--ns-exclude":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--ns-exclude requires a value")
+			}
+			opts.NsExclude = append(opts.NsExclude, splitCommaList(flags[i+1])...)
+			i++
+			continue
+		case "--kubeconfigs":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--kubeconfigs requires a value")
+			}
+			opts.Kubeconfigs = append(opts.Kubeconfigs, splitCommaList(flags[i+1])...)
+			i++
+			continue
+		case "--ns-exclude-prefix":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--ns-exclude-prefix requires a value")
+			}
+			opts.NsExcludePrefix = append(opts.NsExcludePrefix, splitCommaList(flags[i+1])...)
+			i++
+			continue
 		case "--confirm-threshold":
 			if i+1 >= len(flags) {
 				return opts, fmt.Errorf("--confirm-threshold requires a value")
@@ -285,13 +514,48 @@ func parseArgs(argv []string) (CLIOptions, error) {
 		case "--server-dry-run":
 			opts.ServerDryRun = true
 			continue
+		case "--remove-finalizers":
+			opts.RemoveFinalizers = true
+			continue
+		case "--retry-conflict":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--retry-conflict requires a value")
+			}
+			n, err := strconv.Atoi(flags[i+1])
+			if err != nil || n < 0 {
+				return opts, fmt.Errorf("--retry-conflict must be a non-negative integer")
+			}
+			opts.RetryConflict = n
+			i++
+			continue
+		case "--continue-on-error":
+			opts.ContinueOnError = true
+			continue
+		case "--max-parallel":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--max-parallel requires a value")
+			}
+			n, err := strconv.Atoi(flags[i+1])
+			if err != nil || n < 1 {
+				return opts, fmt.Errorf("--max-parallel must be >= 1")
+			}
+			opts.MaxParallel = n
+			i++
+			continue
+		case "--emit-revert":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--emit-revert requires a file path")
+			}
+			opts.EmitRevert = flags[i+1]
+			i++
+			continue
 		case "--older-than":
 			if i+1 >= len(flags) {
 				return opts, fmt.Errorf("--older-than requires a duration value (e.g., 15m, 2h, 7d)")
 			}
-			d, err := time.ParseDuration(flags[i+1])
+			d, err := parseAgeDuration(flags[i+1])
 			if err != nil {
-				return opts, fmt.Errorf("invalid duration for --older-than")
+				return opts, fmt.Errorf("invalid duration for --older-than: %v", err)
 			}
 			opts.OlderThan = d
 			i++
@@ -300,16 +564,31 @@ func parseArgs(argv []string) (CLIOptions, error) {
 			if i+1 >= len(flags) {
 				return opts, fmt.Errorf("--younger-than requires a duration value")
 			}
-			d, err := time.ParseDuration(flags[i+1])
+			d, err := parseAgeDuration(flags[i+1])
 			if err != nil {
-				return opts, fmt.Errorf("invalid duration for --younger-than")
+				return opts, fmt.Errorf("invalid duration for --younger-than: %v", err)
 			}
 			opts.YoungerThan = d
 			i++
 			continue
-		case "--pod-status":
+		case "--as-of":
 			if i+1 >= len(flags) {
-				return opts, fmt.Errorf("--pod-status requires a value")
+				return opts, fmt.Errorf("--as-of requires an RFC3339 timestamp (e.g., 2025-01-02T15:04:05Z)")
+			}
+			t, err := time.Parse(time.RFC3339, flags[i+1])
+			if err != nil {
+				return opts, fmt.Errorf("invalid timestamp for --as-of (expected RFC3339)")
+			}
+			opts.AsOf = t
+			i++
+			continue
+		case "--evicted":
+			// shorthand for --pod-status Evicted
+			opts.PodStatuses = append(opts.PodStatuses, "Evicted")
+			continue
+		case "--status", "--pod-status":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("%s requires a value", f)
 			}
 			opts.PodStatuses = append(opts.PodStatuses, flags[i+1])
 			i++
@@ -323,7 +602,7 @@ func parseArgs(argv []string) (CLIOptions, error) {
 			}
 			kv := flags[i+1]
 			i++
-			lf, err := parseLabelKV(kv, LabelGlob)
+			lf, err := parseLabelSelectorKV(kv)
 			if err != nil {
 				return opts, err
 			}
@@ -365,6 +644,53 @@ func parseArgs(argv []string) (CLIOptions, error) {
 			}
 			opts.LabelFilters = append(opts.LabelFilters, lf)
 			continue
+		case "--label-in", "--label-notin":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("%s requires key=a,b,...", f)
+			}
+			mode := LabelIn
+			if f == "--label-notin" {
+				mode = LabelNotIn
+			}
+			lf, err := parseLabelSetKV(flags[i+1], mode)
+			if err != nil {
+				return opts, fmt.Errorf("%s: %w", f, err)
+			}
+			i++
+			opts.LabelFilters = append(opts.LabelFilters, lf)
+			continue
+		case "--label-gt", "--label-ge", "--label-lt", "--label-le", "--label-eq",
+			"--annotation-gt", "--annotation-ge", "--annotation-lt", "--annotation-le", "--annotation-eq":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("%s requires key=N", f)
+			}
+			lf, err := parseNumericLabelKV(flags[i+1], f[strings.LastIndex(f, "-")+1:])
+			if err != nil {
+				return opts, fmt.Errorf("%s: %w", f, err)
+			}
+			i++
+			if strings.HasPrefix(f, "--label-") {
+				opts.LabelFilters = append(opts.LabelFilters, lf)
+			} else {
+				opts.AnnotationFilters = append(opts.AnnotationFilters, lf)
+			}
+			continue
+		case "--has-label", "--no-label", "--has-annotation", "--no-annotation":
+			if i+1 >= len(flags) || flags[i+1] == "" {
+				return opts, fmt.Errorf("%s requires a key", f)
+			}
+			switch f {
+			case "--has-label":
+				opts.HasLabel = append(opts.HasLabel, flags[i+1])
+			case "--no-label":
+				opts.NoLabel = append(opts.NoLabel, flags[i+1])
+			case "--has-annotation":
+				opts.HasAnnotation = append(opts.HasAnnotation, flags[i+1])
+			default:
+				opts.NoAnnotation = append(opts.NoAnnotation, flags[i+1])
+			}
+			i++
+			continue
 		case "--label-key-regex":
 			if i+1 >= len(flags) {
 				return opts, fmt.Errorf("--label-key-regex requires a regex")
@@ -427,11 +753,100 @@ func parseArgs(argv []string) (CLIOptions, error) {
 			opts.AnnotationKeyRegex = append(opts.AnnotationKeyRegex, flags[i+1])
 			i++
 			continue
+		case "--name-collisions":
+			opts.NameCollisions = true
+			continue
+		case "--require-namespace":
+			opts.RequireNamespace = true
+			continue
+		case "--show-owner":
+			opts.ShowOwner = true
+			continue
+		case "--client-table":
+			opts.ClientTable = true
+			continue
+		case "--terminating":
+			opts.Terminating = true
+			continue
+		case "--stale-replicaset":
+			opts.StaleReplicaSet = true
+			continue
+		case "--replicas-unready":
+			opts.ReplicasUnready = true
+			continue
+		case "--node-not-ready":
+			opts.NodeNotReady = true
+			continue
+		case "--node-memory-pressure":
+			opts.NodePressure = append(opts.NodePressure, "MemoryPressure")
+			continue
+		case "--node-disk-pressure":
+			opts.NodePressure = append(opts.NodePressure, "DiskPressure")
+			continue
+		case "--node-pid-pressure":
+			opts.NodePressure = append(opts.NodePressure, "PIDPressure")
+			continue
+		case "--node-pressure":
+			opts.NodePressure = append(opts.NodePressure, nodePressureConditions...)
+			continue
+		case "--has-finalizers":
+			opts.HasFinalizers = true
+			continue
+		case "--finalizer":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--finalizer requires a value (e.g., kubernetes.io/pvc-protection)")
+			}
+			opts.Finalizers = append(opts.Finalizers, flags[i+1])
+			i++
+			continue
+		case "--managed-by":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--managed-by requires a manager name or glob (e.g., argocd, kubectl-*)")
+			}
+			if _, err := path.Match(flags[i+1], ""); err != nil {
+				return opts, fmt.Errorf("invalid glob for --managed-by: %s", flags[i+1])
+			}
+			opts.ManagedBy = append(opts.ManagedBy, flags[i+1])
+			i++
+			continue
+		case "--condition":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--condition requires TYPE=STATUS (e.g., Available=False)")
+			}
+			cf, err := parseLabelKV(flags[i+1], LabelGlob)
+			if err != nil {
+				return opts, fmt.Errorf("--condition requires TYPE=STATUS (e.g., Available=False)")
+			}
+			switch strings.ToLower(cf.Pattern) {
+			case "true", "false", "unknown":
+			default:
+				return opts, fmt.Errorf("--condition status must be True, False or Unknown: %s", flags[i+1])
+			}
+			opts.Conditions = append(opts.Conditions, cf)
+			i++
+			continue
+		case "--owner-kind":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--owner-kind requires a kind (e.g., ReplicaSet, Job)")
+			}
+			opts.OwnerKinds = append(opts.OwnerKinds, flags[i+1])
+			i++
+			continue
+		case "--owner-name":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--owner-name requires a name or glob")
+			}
+			if _, err := path.Match(flags[i+1], ""); err != nil {
+				return opts, fmt.Errorf("invalid glob for --owner-name: %s", flags[i+1])
+			}
+			opts.OwnerNames = append(opts.OwnerNames, flags[i+1])
+			i++
+			continue
 		case "--node":
 			if i+1 >= len(flags) {
 				return opts, fmt.Errorf("--node requires a value")
 			}
-			opts.NodeExact = append(opts.NodeExact, flags[i+1])
+			opts.NodeExact = append(opts.NodeExact, splitCommaList(flags[i+1])...)
 			i++
 			continue
 		case "--node-prefix":
@@ -448,6 +863,140 @@ func parseArgs(argv []string) (CLIOptions, error) {
 			opts.NodeRegex = append(opts.NodeRegex, flags[i+1])
 			i++
 			continue
+		case "--node-selector":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--node-selector requires key=glob")
+			}
+			lf, err := parseLabelKV(flags[i+1], LabelGlob)
+			if err != nil {
+				return opts, fmt.Errorf("--node-selector requires key=glob")
+			}
+			opts.NodeSelectorFilters = append(opts.NodeSelectorFilters, lf)
+			i++
+			continue
+		case "--no-node-selector":
+			opts.NoNodeSelector = true
+			continue
+		case "--has-affinity":
+			opts.HasAffinity = true
+			continue
+		case "--no-affinity":
+			opts.NoAffinity = true
+			continue
+		case "--tolerates":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--tolerates requires a taint key")
+			}
+			opts.Tolerates = append(opts.Tolerates, flags[i+1])
+			i++
+			continue
+		case "--uses-pvc":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--uses-pvc requires a PVC name or glob (e.g., data-*)")
+			}
+			if _, err := path.Match(flags[i+1], ""); err != nil {
+				return opts, fmt.Errorf("invalid glob for --uses-pvc: %s", flags[i+1])
+			}
+			opts.UsesPVC = append(opts.UsesPVC, flags[i+1])
+			i++
+			continue
+		case "--uses-configmap", "--uses-secret":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("%s requires a name or glob", f)
+			}
+			if _, err := path.Match(flags[i+1], ""); err != nil {
+				return opts, fmt.Errorf("invalid glob for %s: %s", f, flags[i+1])
+			}
+			if f == "--uses-configmap" {
+				opts.UsesConfigMap = append(opts.UsesConfigMap, flags[i+1])
+			} else {
+				opts.UsesSecret = append(opts.UsesSecret, flags[i+1])
+			}
+			i++
+			continue
+		case "--image":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--image requires an image or glob (e.g., 'docker.io/*')")
+			}
+			if _, err := path.Match(flags[i+1], ""); err != nil {
+				return opts, fmt.Errorf("invalid glob for --image: %s", flags[i+1])
+			}
+			opts.Image = append(opts.Image, flags[i+1])
+			i++
+			continue
+		case "--image-regex":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--image-regex requires a regex")
+			}
+			if _, err := regexp.Compile(flags[i+1]); err != nil {
+				return opts, fmt.Errorf("invalid regex for --image-regex: %v", err)
+			}
+			opts.ImageRegex = append(opts.ImageRegex, flags[i+1])
+			i++
+			continue
+		case "--no-readiness-probe":
+			opts.NoReadinessProbe = true
+			continue
+		case "--no-liveness-probe":
+			opts.NoLivenessProbe = true
+			continue
+		case "--grace-period-longer-than":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--grace-period-longer-than requires a value in seconds")
+			}
+			n, err := strconv.ParseInt(flags[i+1], 10, 64)
+			if err != nil || n <= 0 {
+				return opts, fmt.Errorf("--grace-period-longer-than must be a positive number of seconds, got %q", flags[i+1])
+			}
+			opts.GracePeriodLongerThan = n
+			i++
+			continue
+		case "--qos":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--qos requires Guaranteed, Burstable or BestEffort")
+			}
+			switch strings.ToLower(flags[i+1]) {
+			case "guaranteed":
+				opts.QOSClasses = append(opts.QOSClasses, "Guaranteed")
+			case "burstable":
+				opts.QOSClasses = append(opts.QOSClasses, "Burstable")
+			case "besteffort":
+				opts.QOSClasses = append(opts.QOSClasses, "BestEffort")
+			default:
+				return opts, fmt.Errorf("--qos must be Guaranteed, Burstable or BestEffort")
+			}
+			i++
+			continue
+		case "--pod-ip", "--host-ip":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("%s requires a CIDR or glob (e.g., 10.244.0.0/16, 10.244.3.*)", f)
+			}
+			if err := validateIPPattern(flags[i+1]); err != nil {
+				return opts, fmt.Errorf("invalid %s: %w", f, err)
+			}
+			if f == "--pod-ip" {
+				opts.PodIPs = append(opts.PodIPs, flags[i+1])
+			} else {
+				opts.HostIPs = append(opts.HostIPs, flags[i+1])
+			}
+			i++
+			continue
+		case "--restart-policy":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--restart-policy requires Always, OnFailure or Never")
+			}
+			switch strings.ToLower(flags[i+1]) {
+			case "always":
+				opts.RestartPolicy = "Always"
+			case "onfailure":
+				opts.RestartPolicy = "OnFailure"
+			case "never":
+				opts.RestartPolicy = "Never"
+			default:
+				return opts, fmt.Errorf("--restart-policy must be Always, OnFailure or Never")
+			}
+			i++
+			continue
 		case "--restarts":
 			if i+1 >= len(flags) {
 				return opts, fmt.Errorf("--restarts requires an expression like >3 or <=1")
@@ -455,9 +1004,62 @@ func parseArgs(argv []string) (CLIOptions, error) {
 			opts.RestartExpr = flags[i+1]
 			i++
 			continue
+		case "--ready-containers":
+			if i+1 >= len(flags) || !intExprPattern.MatchString(flags[i+1]) {
+				return opts, fmt.Errorf("--ready-containers requires an expression like <2 or >=1")
+			}
+			opts.ReadyContainersExpr = flags[i+1]
+			i++
+			continue
+		case "--ordinal-range":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--ordinal-range requires a range like 1-3")
+			}
+			lo, hi, err := parseOrdinalRange(flags[i+1])
+			if err != nil {
+				return opts, fmt.Errorf("invalid --ordinal-range %q: %w", flags[i+1], err)
+			}
+			opts.OrdinalRangeSet, opts.OrdinalMin, opts.OrdinalMax = true, lo, hi
+			i++
+			continue
+		case "--ready-flapped-within":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--ready-flapped-within requires a duration value (e.g., 10m, 1d)")
+			}
+			d, err := parseAgeDuration(flags[i+1])
+			if err != nil || d <= 0 {
+				return opts, fmt.Errorf("invalid duration for --ready-flapped-within")
+			}
+			opts.ReadyFlappedWithin = d
+			i++
+			continue
+		case "--restart-delta":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--restart-delta requires a value")
+			}
+			n, err := strconv.Atoi(flags[i+1])
+			if err != nil || n < 1 {
+				return opts, fmt.Errorf("--restart-delta must be >= 1")
+			}
+			opts.RestartDelta = n
+			i++
+			continue
+		case "--from-snapshot":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--from-snapshot requires a file path")
+			}
+			opts.FromSnapshot = flags[i+1]
+			i++
+			continue
 		case "--containers-not-ready":
 			opts.ContainersNotReady = true
 			continue
+		case "--unscheduled":
+			opts.Unscheduled = true
+			continue
+		case "--scheduling-gated":
+			opts.SchedulingGated = true
+			continue
 		case "--reason":
 			if i+1 >= len(flags) {
 				return opts, fmt.Errorf("--reason requires a value (e.g., OOMKilled)")
@@ -465,6 +1067,31 @@ func parseArgs(argv []string) (CLIOptions, error) {
 			opts.ReasonFilters = append(opts.ReasonFilters, flags[i+1])
 			i++
 			continue
+		case "--churning":
+			opts.Churning = true
+			continue
+		case "--churning-age":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--churning-age requires a duration value (e.g., 24h, 7d)")
+			}
+			d, err := parseAgeDuration(flags[i+1])
+			if err != nil || d <= 0 {
+				return opts, fmt.Errorf("invalid duration for --churning-age")
+			}
+			opts.ChurningAge = d
+			i++
+			continue
+		case "--churning-restarts":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--churning-restarts requires a value")
+			}
+			n, err := strconv.Atoi(flags[i+1])
+			if err != nil || n < 1 {
+				return opts, fmt.Errorf("--churning-restarts must be a positive integer")
+			}
+			opts.ChurningRestarts = n
+			i++
+			continue
 		case "--container-name":
 			if i+1 >= len(flags) {
 				return opts, fmt.Errorf("--container-name requires a value")
@@ -472,16 +1099,167 @@ func parseArgs(argv []string) (CLIOptions, error) {
 			opts.ContainerScope = flags[i+1]
 			i++
 			continue
+		case "--metrics":
+			opts.Metrics = true
+			continue
+		case "--json":
+			opts.JSON = true
+			continue
+		case "--format":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--format requires a value (json)")
+			}
+			if flags[i+1] != "json" {
+				return opts, fmt.Errorf("--format only supports json, got %q", flags[i+1])
+			}
+			opts.JSON = true
+			i++
+			continue
+		case "--format=json":
+			opts.JSON = true
+			continue
+		case "--bare":
+			opts.Bare = true
+			continue
+		case "--count-by":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--count-by requires a field (namespace|node|phase|label:KEY)")
+			}
+			field := flags[i+1]
+			switch {
+			case field == "namespace", field == "node", field == "phase":
+			case strings.HasPrefix(field, "label:") && len(field) > len("label:"):
+			default:
+				return opts, fmt.Errorf("--count-by must be namespace, node, phase or label:KEY")
+			}
+			opts.CountBy = field
+			i++
+			continue
+		case "--events":
+			opts.Events = true
+			continue
+		case "--names-status":
+			opts.NamesStatus = true
+			continue
+		case "--limit":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--limit requires a count")
+			}
+			n, err := strconv.Atoi(flags[i+1])
+			if err != nil || n <= 0 {
+				return opts, fmt.Errorf("--limit must be a positive integer")
+			}
+			opts.Limit = n
+			i++
+			continue
+		case "--sample":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--sample requires a count")
+			}
+			n, err := strconv.Atoi(flags[i+1])
+			if err != nil || n <= 0 {
+				return opts, fmt.Errorf("--sample must be a positive integer")
+			}
+			opts.Sample = n
+			i++
+			continue
+		case "--seed":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--seed requires an integer")
+			}
+			n, err := strconv.ParseInt(flags[i+1], 10, 64)
+			if err != nil {
+				return opts, fmt.Errorf("--seed must be an integer")
+			}
+			opts.Seed = n
+			opts.SeedSet = true
+			i++
+			continue
+		case "--error-on-empty":
+			opts.ErrorOnEmpty = true
+			continue
+		case "--suggest":
+			opts.Suggest = true
+			continue
+		case "--no-suggest":
+			opts.NoSuggest = true
+			continue
+		case "--names-only", "-q":
+			opts.NamesOnly = true
+			continue
+		case "--print0":
+			// NUL-separated names for xargs -0; implies --names-only
+			opts.NamesOnly = true
+			opts.Print0 = true
+			continue
+		case "--output-separator":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--output-separator requires a value")
+			}
+			opts.OutputSeparator = flags[i+1]
+			i++
+			continue
+		case "--pager":
+			opts.Pager = true
+			continue
+		case "--no-pager":
+			opts.NoPager = true
+			continue
+		case "--compact-describe":
+			opts.CompactDescribe = true
+			continue
+		case "--poll-until-empty":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--poll-until-empty requires a duration value (e.g., 30s, 5m)")
+			}
+			d, err := time.ParseDuration(flags[i+1])
+			if err != nil || d <= 0 {
+				return opts, fmt.Errorf("invalid duration for --poll-until-empty")
+			}
+			opts.PollTimeout = d
+			pollUntilEmpty = true
+			i++
+			continue
+		case "--poll-until-count":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--poll-until-count requires a value")
+			}
+			n, err := strconv.Atoi(flags[i+1])
+			if err != nil || n < 0 {
+				return opts, fmt.Errorf("--poll-until-count must be a non-negative integer")
+			}
+			opts.PollUntilCount = n
+			pollUntilCount = true
+			if opts.PollTimeout == 0 {
+				opts.PollTimeout = defaultPollTimeout
+			}
+			i++
+			continue
+		case "--poll-timeout":
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--poll-timeout requires a duration value")
+			}
+			d, err := time.ParseDuration(flags[i+1])
+			if err != nil || d <= 0 {
+				return opts, fmt.Errorf("invalid duration for --poll-timeout")
+			}
+			opts.PollTimeout = d
+			pollTimeout = true
+			i++
+			continue
 		case "--group-by-label":
 			if i+1 >= len(flags) {
 				return opts, fmt.Errorf("--group-by-label requires a key")
 			}
-			opts.GroupByLabel = flags[i+1]
+			opts.GroupByLabel = append(opts.GroupByLabel, splitCommaList(flags[i+1])...)
 			i++
 			continue
 		case "--colorize-labels":
 			opts.ColorizeLabels = true
 			continue
+		case "--group-by-owner":
+			opts.GroupByOwner = true
+			continue
 		}
 
 		// discovery-affecting passthrough flags we track specially
@@ -541,8 +1319,111 @@ func parseArgs(argv []string) (CLIOptions, error) {
 			continue
 		}
 
+		// Connection flags take a value and apply to every kubectl call
+		if isConnectionFlag(f) {
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("%s requires a value", f)
+			}
+			opts.DiscoveryFlags = append(opts.DiscoveryFlags, f, flags[i+1])
+			opts.FinalFlags = append(opts.FinalFlags, f, flags[i+1])
+			i++
+			continue
+		}
+
+		// Output format only applies to the final kubectl call (discovery always uses -o json)
+		if f == "-o" || f == "--output" {
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("%s requires a value", f)
+			}
+			opts.FinalFlags = append(opts.FinalFlags, f, flags[i+1])
+			i++
+			continue
+		}
+		// --sort-by names either a key wild sorts the matches by or a kubectl JSONPath
+		if f == "--sort-by" {
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--sort-by requires a value")
+			}
+			if isSortKey(flags[i+1]) {
+				opts.SortBy = flags[i+1]
+			} else {
+				opts.FinalFlags = append(opts.FinalFlags, f, flags[i+1])
+			}
+			i++
+			continue
+		}
+		if f == "--color" || strings.HasPrefix(f, "--color=") {
+			mode := strings.TrimPrefix(f, "--color=")
+			if f == "--color" {
+				if i+1 >= len(flags) {
+					return opts, fmt.Errorf("--color requires always, never or auto")
+				}
+				mode = flags[i+1]
+				i++
+			}
+			switch mode {
+			case "always", "never":
+				colorMode = mode
+				opts.NoColor = mode == "never"
+			case "auto":
+				colorMode = ""
+				opts.NoColor = false
+			default:
+				return opts, fmt.Errorf("--color must be always, never or auto, got %q", mode)
+			}
+			continue
+		}
+		if v, ok := strings.CutPrefix(f, "--sort-by="); ok && isSortKey(v) {
+			opts.SortBy = v
+			continue
+		}
+		if f == "--sort-reverse" {
+			opts.SortReverse = true
+			continue
+		}
+
+		// Native label selector: let the server pre-filter during discovery; wild label
+		// filters (--label etc.) then apply client-side on the reduced set. Not forwarded
+		// to final calls since kubectl rejects explicit names combined with a selector.
+		if f == "-l" || f == "--selector" {
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("%s requires a selector value", f)
+			}
+			opts.DiscoveryFlags = append(opts.DiscoveryFlags, f, flags[i+1])
+			i++
+			continue
+		}
+		if strings.HasPrefix(f, "-l=") || strings.HasPrefix(f, "--selector=") {
+			opts.DiscoveryFlags = append(opts.DiscoveryFlags, f)
+			continue
+		}
+		// Field selectors likewise only narrow discovery: kubectl rejects them on
+		// `delete <name>` and friends.
+		if f == "--field-selector" {
+			if i+1 >= len(flags) {
+				return opts, fmt.Errorf("--field-selector requires a selector value")
+			}
+			opts.DiscoveryFlags = append(opts.DiscoveryFlags, f, flags[i+1])
+			i++
+			continue
+		}
+		if strings.HasPrefix(f, "--field-selector=") {
+			opts.DiscoveryFlags = append(opts.DiscoveryFlags, f)
+			continue
+		}
+
+		// --overwrite belongs to `kubectl label/annotate`; `get` would reject it
+		if f == "--overwrite" || strings.HasPrefix(f, "--overwrite=") {
+			opts.FinalFlags = append(opts.FinalFlags, f)
+			continue
+		}
+
 		// Check if this looks like a pattern (non-flag token) rather than a passthrough flag
 		// Patterns can appear anywhere in the command, not just at position 2
+		if isMetadataVerb(opts.Verb) && isMetadataMutation(f) {
+			opts.Mutations = append(opts.Mutations, f)
+			continue
+		}
 		if !strings.HasPrefix(f, "-") {
 			// This is a positional argument (likely a pattern), add to includes
 			// If we had a default pattern, remove it before adding the real one
@@ -577,6 +1458,11 @@ func parseArgs(argv []string) (CLIOptions, error) {
 			if len(opts.Include) == 0 {
 				opts.Include = []string{""}
 			}
+		case MatchExact:
+			// A defaulted "*" would be taken literally; require an explicit name instead
+			if len(opts.Include) <= 1 {
+				return opts, fmt.Errorf("--exact requires a name")
+			}
 		}
 	}
 	// If we had inserted a default include and user also provided an explicit include (e.g., -p/--prefix/--match), drop the default
@@ -585,10 +1471,159 @@ func parseArgs(argv []string) (CLIOptions, error) {
 		opts.Include = opts.Include[1:]
 	}
 	opts.ExtraFinal = append(opts.ExtraFinal, tail...)
+	// Like a wildcard -n, namespace excludes only make sense across namespaces
+	if (len(opts.NsExclude) > 0 || len(opts.NsExcludePrefix) > 0) && !opts.AllNamespaces && opts.Namespace == "" {
+		opts.AllNamespaces = true
+		opts.DiscoveryFlags = append(opts.DiscoveryFlags, "-A")
+	}
+	if opts.Verb == VerbLogs {
+		opts.Resource = "pods"
+		if opts.ContainerScope != "" && !containsFlag(opts.FinalFlags, "-c") && !containsFlag(opts.FinalFlags, "--container") {
+			opts.FinalFlags = append(opts.FinalFlags, "-c", opts.ContainerScope)
+		}
+	}
+	if opts.NameCollisions && !opts.AllNamespaces {
+		return opts, fmt.Errorf("--name-collisions requires -A")
+	}
+	if opts.ShowOwner {
+		if opts.Verb != VerbGet || !opts.AllNamespaces {
+			return opts, fmt.Errorf("--show-owner is only supported with get -A")
+		}
+		if f := outputFormat(opts.FinalFlags); f != "" && f != "wide" {
+			return opts, fmt.Errorf("--show-owner only works with table output, not -o %s", f)
+		}
+	}
+	if len(opts.Kubeconfigs) > 0 && (containsFlag(opts.DiscoveryFlags, "--kubeconfig") || containsFlagWithPrefix(opts.DiscoveryFlags, "--kubeconfig=")) {
+		return opts, fmt.Errorf("--kubeconfigs cannot be combined with --kubeconfig")
+	}
+	if opts.StaleReplicaSet && !isReplicaSetResource(opts.Resource) {
+		return opts, fmt.Errorf("--stale-replicaset only applies to replicasets, not %s", opts.Resource)
+	}
+	if opts.ReplicasUnready && !isReplicatedResource(opts.Resource) {
+		return opts, fmt.Errorf("--replicas-unready only applies to deployments, statefulsets and replicasets, not %s", opts.Resource)
+	}
+	if (opts.NodeNotReady || len(opts.NodePressure) > 0) && !isNodeResource(opts.Resource) {
+		return opts, fmt.Errorf("--node-not-ready and --node-*-pressure only apply to nodes, not %s", opts.Resource)
+	}
+	if (len(opts.PodIPs) > 0 || len(opts.HostIPs) > 0) && !isPodsResource(opts.Resource) {
+		return opts, fmt.Errorf("--pod-ip and --host-ip only apply to pods, not %s", opts.Resource)
+	}
+	// Pod spec/status filters would otherwise be skipped and match every object
+	if flag := podOnlyFlag(opts); flag != "" && !isPodsResource(opts.Resource) {
+		return opts, fmt.Errorf("%s only applies to pods, not %s", flag, opts.Resource)
+	}
+	if opts.HasAffinity && opts.NoAffinity {
+		return opts, fmt.Errorf("--has-affinity and --no-affinity are mutually exclusive")
+	}
+	if opts.SeedSet && opts.Sample == 0 {
+		return opts, fmt.Errorf("--seed requires --sample")
+	}
+	if opts.Verb == VerbScale && opts.Replicas < 0 {
+		return opts, fmt.Errorf("scale requires --replicas N")
+	}
+	if opts.Verb != VerbScale && opts.Replicas >= 0 {
+		return opts, fmt.Errorf("--replicas is only supported with scale")
+	}
+	if isMetadataVerb(opts.Verb) && len(opts.Mutations) == 0 {
+		return opts, fmt.Errorf("%s requires at least one key=value (or key- to remove)", opts.Verb)
+	}
+	if opts.Verb == VerbWait && !containsFlag(opts.ExtraFinal, "--for") && !containsFlagWithPrefix(opts.ExtraFinal, "--for=") {
+		return opts, fmt.Errorf("wait requires a condition after --, e.g. -- --for=condition=Ready --timeout=120s")
+	}
+	if opts.Verb == VerbPatch && !hasPatchBody(opts.ExtraFinal) {
+		return opts, fmt.Errorf("patch requires the patch after --, e.g. -- --type=merge -p '{\"spec\":{...}}'")
+	}
+	if opts.Verb == VerbRolloutRestart && !isRolloutResource(opts.Resource) {
+		return opts, fmt.Errorf("rollout-restart only supports deployments, daemonsets and statefulsets, not %s", opts.Resource)
+	}
+	if colorMode == "" && !colorByDefault() {
+		opts.NoColor = true
+	}
+	if opts.RemoveFinalizers && opts.Verb != VerbDelete {
+		return opts, fmt.Errorf("--remove-finalizers is only supported with delete")
+	}
+	structured := 0
+	for _, set := range []bool{opts.Metrics, opts.JSON, opts.NamesStatus, opts.NamesOnly, opts.CountBy != "", opts.Events} {
+		if set {
+			structured++
+		}
+	}
+	if structured > 0 && opts.Verb != VerbGet {
+		return opts, fmt.Errorf("--metrics/--json/--names-status/--names-only/--count-by/--events are only supported with get")
+	}
+	if structured > 1 {
+		return opts, fmt.Errorf("--metrics, --json, --names-status, --names-only, --count-by and --events are mutually exclusive")
+	}
+	// One document per cluster wouldn't parse as a whole
+	if structured > 0 && len(opts.Kubeconfigs) > 0 {
+		return opts, fmt.Errorf("--kubeconfigs can't be combined with --metrics/--json/--names-status/--names-only/--count-by/--events")
+	}
+	if opts.ClientTable {
+		if opts.Verb != VerbGet || !opts.AllNamespaces || !isPodsResource(opts.Resource) {
+			return opts, fmt.Errorf("--client-table is only supported with get pods -A")
+		}
+		if f := outputFormat(opts.FinalFlags); f != "" && f != "wide" {
+			return opts, fmt.Errorf("--client-table only renders table output, not -o %s", f)
+		}
+		if containsFlag(opts.FinalFlags, "--sort-by") || containsFlagWithPrefix(opts.FinalFlags, "--sort-by=") {
+			return opts, fmt.Errorf("--client-table only sorts by age, restarts, ready, name, node or namespace")
+		}
+		if opts.ShowOwner || structured > 0 {
+			return opts, fmt.Errorf("--client-table cannot be combined with --show-owner or --metrics/--json/--names-status/--names-only/--count-by/--events")
+		}
+	}
+	if opts.SortReverse && opts.SortBy == "" {
+		return opts, fmt.Errorf("--sort-reverse requires --sort-by age|restarts|ready|name|node|namespace")
+	}
+	if (opts.RestartDelta > 0) != (opts.FromSnapshot != "") {
+		return opts, fmt.Errorf("--restart-delta and --from-snapshot must be used together")
+	}
+	if pollUntilEmpty && pollUntilCount {
+		return opts, fmt.Errorf("--poll-until-empty and --poll-until-count are mutually exclusive")
+	}
+	if pollTimeout && !pollUntilCount {
+		if pollUntilEmpty {
+			return opts, fmt.Errorf("--poll-until-empty takes its own duration; --poll-timeout only applies to --poll-until-count")
+		}
+		return opts, fmt.Errorf("--poll-timeout requires --poll-until-count")
+	}
+	if opts.PollTimeout > 0 && opts.Verb != VerbGet {
+		return opts, fmt.Errorf("--poll-until-empty/--poll-until-count are only supported with get")
+	}
 
 	return opts, nil
 }
 
+// outputFormat returns the value of a -o/--output flag, or "" when none is given.
+func outputFormat(flags []string) string {
+	for i, f := range flags {
+		switch {
+		case (f == "-o" || f == "--output") && i+1 < len(flags):
+			return flags[i+1]
+		case strings.HasPrefix(f, "-o="):
+			return strings.TrimPrefix(f, "-o=")
+		case strings.HasPrefix(f, "--output="):
+			return strings.TrimPrefix(f, "--output=")
+		}
+	}
+	return ""
+}
+
+// intExprPattern matches the expressions understood by compareIntExpr.
+var intExprPattern = regexp.MustCompile(`^(>=|<=|>|<|=)?[0-9]+$`)
+
+// splitCommaList splits a flag value like "a, b,,c" into its trimmed,
+// non-empty entries.
+func splitCommaList(v string) []string {
+	var out []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
 func indexOf(ss []string, s string) int {
 	for i, v := range ss {
 		if v == s {
@@ -625,3 +1660,243 @@ func globToRegex(glob string) string {
 	b.WriteString("$")
 	return b.String()
 }
+
+// parseAgeDuration is time.ParseDuration plus day and week units (d=24h, w=168h),
+// so ages like 7d, 2w or 1d12h work alongside Go forms like 90m and 1h30m.
+// Phrases like "3 days ago" are accepted too (see parseAgoDuration).
+func parseAgeDuration(s string) (time.Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	if strings.ContainsAny(s, " \t") {
+		return parseAgoDuration(s)
+	}
+	var total time.Duration
+	rest := s
+	for rest != "" {
+		i := 0
+		for i < len(rest) && (rest[i] == '.' || (rest[i] >= '0' && rest[i] <= '9')) {
+			i++
+		}
+		j := i
+		for j < len(rest) && rest[j] != '.' && (rest[j] < '0' || rest[j] > '9') {
+			j++
+		}
+		if i == 0 || j == i {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		var unit time.Duration
+		switch rest[i:j] {
+		case "d":
+			unit = 24 * time.Hour
+		case "w":
+			unit = 7 * 24 * time.Hour
+		}
+		if unit == 0 {
+			d, err := time.ParseDuration(rest[:j])
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			total += d
+		} else {
+			n, err := strconv.ParseFloat(rest[:i], 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			total += time.Duration(n * float64(unit))
+		}
+		rest = rest[j:]
+	}
+	return total, nil
+}
+
+// agoUnits are the units accepted in "N <unit> ago" phrases.
+var agoUnits = map[string]time.Duration{
+	"minute": time.Minute, "minutes": time.Minute, "min": time.Minute, "mins": time.Minute,
+	"hour": time.Hour, "hours": time.Hour,
+	"day": 24 * time.Hour, "days": 24 * time.Hour,
+	"week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+// parseAgoDuration parses "N minutes/hours/days/weeks ago" (N a whole number).
+// Months and years are rejected: their length depends on the calendar.
+func parseAgoDuration(s string) (time.Duration, error) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) != 3 || fields[2] != "ago" {
+		return 0, fmt.Errorf("invalid duration %q (expected e.g. 90m, 7d or '3 days ago')", s)
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid duration %q: %q is not a whole number", s, fields[0])
+	}
+	unit, ok := agoUnits[fields[1]]
+	if !ok {
+		switch strings.TrimSuffix(fields[1], "s") {
+		case "month", "year":
+			return 0, fmt.Errorf("ambiguous duration %q: months and years vary in length, use days or weeks", s)
+		}
+		return 0, fmt.Errorf("invalid duration %q: unknown unit %q (minutes, hours, days, weeks)", s, fields[1])
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// isSortKey reports whether v is a key wild sorts matches by (other --sort-by values are JSONPaths).
+func isSortKey(v string) bool {
+	switch v {
+	case "age", "restarts", "ready", "name", "node", "namespace":
+		return true
+	}
+	return false
+}
+
+// parseOrdinalRange parses "M-N" (or a single "N") into an inclusive range.
+func parseOrdinalRange(s string) (int, int, error) {
+	lo, hi, found := strings.Cut(s, "-")
+	if !found {
+		hi = lo
+	}
+	m, err := strconv.Atoi(lo)
+	if err != nil || m < 0 {
+		return 0, 0, fmt.Errorf("expected M-N with non-negative integers")
+	}
+	n, err := strconv.Atoi(hi)
+	if err != nil || n < 0 {
+		return 0, 0, fmt.Errorf("expected M-N with non-negative integers")
+	}
+	if m > n {
+		return 0, 0, fmt.Errorf("start %d is greater than end %d", m, n)
+	}
+	return m, n, nil
+}
+
+// podOnlyFlag returns the first pod-only filter flag set in opts, or "".
+func podOnlyFlag(opts CLIOptions) string {
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"--node-selector", len(opts.NodeSelectorFilters) > 0},
+		{"--no-node-selector", opts.NoNodeSelector},
+		{"--has-affinity", opts.HasAffinity},
+		{"--no-affinity", opts.NoAffinity},
+		{"--tolerates", len(opts.Tolerates) > 0},
+		{"--no-readiness-probe", opts.NoReadinessProbe},
+		{"--no-liveness-probe", opts.NoLivenessProbe},
+		{"--grace-period-longer-than", opts.GracePeriodLongerThan > 0},
+		{"--restart-policy", opts.RestartPolicy != ""},
+		{"--uses-pvc", len(opts.UsesPVC) > 0},
+		{"--uses-configmap", len(opts.UsesConfigMap) > 0},
+		{"--uses-secret", len(opts.UsesSecret) > 0},
+		{"--qos", len(opts.QOSClasses) > 0},
+		{"--image", len(opts.Image) > 0},
+		{"--image-regex", len(opts.ImageRegex) > 0},
+		{"--ready-containers", opts.ReadyContainersExpr != ""},
+		{"--ordinal-range", opts.OrdinalRangeSet},
+		{"--ready-flapped-within", opts.ReadyFlappedWithin > 0},
+		{"--restart-delta", opts.RestartDelta > 0},
+		{"--unscheduled", opts.Unscheduled},
+		{"--scheduling-gated", opts.SchedulingGated},
+		{"--churning", opts.Churning},
+	} {
+		if f.set {
+			return f.name
+		}
+	}
+	return ""
+}
+
+func isPodsResource(r string) bool {
+	switch strings.ToLower(r) {
+	case "pods", "pod", "po":
+		return true
+	}
+	return false
+}
+
+// validateIPPattern checks an --pod-ip/--host-ip value: a CIDR when it contains
+// '/', otherwise a glob.
+func validateIPPattern(p string) error {
+	if strings.Contains(p, "/") {
+		if _, _, err := net.ParseCIDR(p); err != nil {
+			return fmt.Errorf("bad CIDR %q", p)
+		}
+		return nil
+	}
+	if _, err := path.Match(p, ""); err != nil {
+		return fmt.Errorf("bad glob %q", p)
+	}
+	return nil
+}
+
+// isMetadataVerb reports whether v edits labels or annotations of the matched objects.
+func isMetadataVerb(v Verb) bool {
+	return v == VerbLabel || v == VerbAnnotate
+}
+
+// isMetadataMutation reports whether a label/annotate argument is a key=value
+// assignment or a key- removal rather than a name pattern.
+func isMetadataMutation(s string) bool {
+	if strings.HasPrefix(s, "-") {
+		return false
+	}
+	if strings.Contains(s, "=") {
+		return true
+	}
+	return len(s) > 1 && strings.HasSuffix(s, "-") && !strings.ContainsAny(s, "*?[")
+}
+
+// hasPatchBody reports whether the passthrough tail carries kubectl patch's -p/--patch or --patch-file.
+func hasPatchBody(extra []string) bool {
+	for _, f := range extra {
+		if f == "-p" || f == "--patch" || f == "--patch-file" ||
+			strings.HasPrefix(f, "-p=") || strings.HasPrefix(f, "--patch=") || strings.HasPrefix(f, "--patch-file=") {
+			return true
+		}
+	}
+	return false
+}
+
+// isReplicaSetResource reports whether r names ReplicaSets.
+func isReplicaSetResource(r string) bool {
+	switch strings.ToLower(strings.TrimSuffix(r, ".apps")) {
+	case "replicasets", "replicaset", "rs":
+		return true
+	}
+	return false
+}
+
+// isNodeResource reports whether r names the core nodes resource.
+func isNodeResource(r string) bool {
+	switch strings.ToLower(r) {
+	case "nodes", "node", "no":
+		return true
+	}
+	return false
+}
+
+// nodePressureConditions are the node conditions --node-pressure checks.
+var nodePressureConditions = []string{"MemoryPressure", "DiskPressure", "PIDPressure"}
+
+// isReplicatedResource reports whether r is a workload with spec.replicas and status.readyReplicas.
+func isReplicatedResource(r string) bool {
+	switch strings.ToLower(strings.TrimSuffix(r, ".apps")) {
+	case "deployments", "deployment", "deploy",
+		"statefulsets", "statefulset", "sts":
+		return true
+	}
+	return isReplicaSetResource(r)
+}
+
+// isRolloutResource reports whether r is a workload kind `kubectl rollout restart` accepts.
+func isRolloutResource(r string) bool {
+	switch strings.ToLower(strings.TrimSuffix(r, ".apps")) {
+	case "deployments", "deployment", "deploy",
+		"daemonsets", "daemonset", "ds",
+		"statefulsets", "statefulset", "sts":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// compactDescribeRunner captures kubectl output and strips "Managed Fields" sections
+// before printing, so --compact-describe can reuse the normal batched describe path.
+type compactDescribeRunner struct {
+	Runner
+	out io.Writer
+}
+
+func (c compactDescribeRunner) RunKubectl(args []string) error {
+	stdout, stderr, err := c.CaptureKubectl(args)
+	if len(stderr) > 0 {
+		os.Stderr.Write(stderr)
+	}
+	if _, werr := io.WriteString(c.out, stripManagedFields(string(stdout))); werr != nil && err == nil {
+		err = werr
+	}
+	return err
+}
+
+// stripManagedFields removes every "Managed Fields:" block from kubectl describe
+// output: the header line plus all following lines indented deeper than it.
+func stripManagedFields(s string) string {
+	lines := strings.SplitAfter(s, "\n")
+	var b strings.Builder
+	skipIndent := -1
+	for _, ln := range lines {
+		trimmed := strings.TrimLeft(ln, " ")
+		indent := len(ln) - len(trimmed)
+		if skipIndent >= 0 {
+			if strings.TrimSpace(ln) == "" || indent > skipIndent {
+				continue
+			}
+			skipIndent = -1
+		}
+		if strings.TrimSpace(trimmed) == "Managed Fields:" {
+			skipIndent = indent
+			continue
+		}
+		b.WriteString(ln)
+	}
+	return b.String()
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// eventPartial is the subset of a core/v1 Event used by --events.
+type eventPartial struct {
+	InvolvedObject struct {
+		Kind      string `json:"kind"`
+		Namespace string `json:"namespace"`
+		Name      string `json:"name"`
+	} `json:"involvedObject"`
+	Type          string `json:"type"`
+	Reason        string `json:"reason"`
+	Message       string `json:"message"`
+	LastTimestamp string `json:"lastTimestamp"`
+	// Events written through events.k8s.io only carry eventTime
+	EventTime string `json:"eventTime"`
+	Metadata  struct {
+		CreationTimestamp string `json:"creationTimestamp"`
+	} `json:"metadata"`
+}
+
+// lastSeen returns when the event last occurred, falling back from lastTimestamp
+// to eventTime and the creation time.
+func (e eventPartial) lastSeen() time.Time {
+	for _, s := range []string{e.LastTimestamp, e.EventTime, e.Metadata.CreationTimestamp} {
+		if s == "" {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// runEvents lists events cluster-wide and prints the ones whose involvedObject is
+// in the matched set, oldest first, like `kubectl events`.
+func runEvents(w io.Writer, runner Runner, opts CLIOptions, matched []matchedRef) error {
+	args := append([]string{"get", "events", "-A", "-o", "json"}, connectionFlags(opts.DiscoveryFlags)...)
+	out, errOut, err := runner.CaptureKubectl(args)
+	if err != nil {
+		if len(errOut) > 0 {
+			return errors.New(strings.TrimSpace(string(errOut)))
+		}
+		return err
+	}
+	var list struct {
+		Items []eventPartial `json:"items"`
+	}
+	if err := json.Unmarshal(out, &list); err != nil {
+		return fmt.Errorf("failed to parse kubectl json output: %w", err)
+	}
+	events := matchedEvents(list.Items, matched)
+	if len(events) == 0 {
+		fmt.Fprintf(os.Stderr, "No events found for %d matched %s.\n", len(matched), displayResource(opts.Resource))
+		return nil
+	}
+	printEvents(w, events, now(), opts.AllNamespaces, !containsFlag(opts.FinalFlags, "--no-headers"))
+	return nil
+}
+
+// matchedEvents keeps events about matched objects, sorted by last occurrence.
+// The kind is compared only when discovery reported one for the object.
+func matchedEvents(events []eventPartial, matched []matchedRef) []eventPartial {
+	kinds := make(map[string]string, len(matched))
+	for _, m := range matched {
+		kinds[m.ns+"/"+m.name] = m.kind
+	}
+	var kept []eventPartial
+	for _, e := range events {
+		kind, ok := kinds[e.InvolvedObject.Namespace+"/"+e.InvolvedObject.Name]
+		if !ok || (kind != "" && !strings.EqualFold(kind, e.InvolvedObject.Kind)) {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].lastSeen().Before(kept[j].lastSeen())
+	})
+	return kept
+}
+
+func printEvents(w io.Writer, events []eventPartial, now time.Time, allNamespaces, headers bool) {
+	tw := tabwriter.NewWriter(w, 6, 4, 3, ' ', 0)
+	if headers {
+		if allNamespaces {
+			fmt.Fprint(tw, "NAMESPACE\t")
+		}
+		fmt.Fprintln(tw, "LAST SEEN\tTYPE\tREASON\tOBJECT\tMESSAGE")
+	}
+	for _, e := range events {
+		if allNamespaces {
+			fmt.Fprintf(tw, "%s\t", e.InvolvedObject.Namespace)
+		}
+		seen := "<unknown>"
+		if t := e.lastSeen(); !t.IsZero() {
+			seen = humanAge(now.Sub(t))
+		}
+		object := strings.ToLower(e.InvolvedObject.Kind) + "/" + e.InvolvedObject.Name
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", seen, e.Type, e.Reason, object, strings.TrimSpace(e.Message))
+	}
+	tw.Flush()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// runAcrossKubeconfigs runs the command once per --kubeconfigs file with KUBECONFIG
+// pointing at it, so discovery and the final kubectl calls hit the same cluster.
+// Each file's output is preceded by a "==> file (context) <==" header on stderr,
+// so stdout is exactly what kubectl printed. A failing
+// cluster doesn't stop the others; its error is collected and returned at the end.
+func runAcrossKubeconfigs(runner Runner, opts CLIOptions) error {
+	prev, hadPrev := os.LookupEnv("KUBECONFIG")
+	defer func() {
+		if hadPrev {
+			os.Setenv("KUBECONFIG", prev)
+		} else {
+			os.Unsetenv("KUBECONFIG")
+		}
+	}()
+	files := opts.Kubeconfigs
+	opts.Kubeconfigs = nil
+	var errs []error
+	empty := 0
+	for i, file := range files {
+		os.Setenv("KUBECONFIG", file)
+		// Resource scope and CRD names are per cluster
+		clearResourceCaches()
+		if i > 0 {
+			fmt.Fprintln(os.Stderr)
+		}
+		fmt.Fprintln(os.Stderr, kubeconfigHeader(runner, file))
+		err := runCommand(runner, opts)
+		switch {
+		case err == nil:
+		case errors.Is(err, errNoMatches):
+			empty++
+		default:
+			errs = append(errs, fmt.Errorf("%s: %w", file, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if empty == len(files) {
+		return errNoMatches
+	}
+	return nil
+}
+
+// kubeconfigHeader names the file and, when kubectl can tell, its current context.
+func kubeconfigHeader(runner Runner, file string) string {
+	out, _, err := runner.CaptureKubectl([]string{"config", "current-context"})
+	if ctx := strings.TrimSpace(string(out)); err == nil && ctx != "" {
+		return fmt.Sprintf("==> %s (%s) <==", file, ctx)
+	}
+	return fmt.Sprintf("==> %s <==", file)
+}
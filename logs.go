@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// prefixWriter writes complete lines to dst, each preceded by prefix. Writers for
+// different pods share mu so lines from concurrent streams never interleave.
+type prefixWriter struct {
+	mu      *sync.Mutex
+	dst     io.Writer
+	prefix  string
+	pending []byte
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.pending = append(p.pending, b...)
+	for {
+		i := bytes.IndexByte(p.pending, '\n')
+		if i < 0 {
+			break
+		}
+		if err := p.emit(p.pending[:i+1]); err != nil {
+			return 0, err
+		}
+		p.pending = p.pending[i+1:]
+	}
+	return len(b), nil
+}
+
+// Flush writes a trailing line that had no newline.
+func (p *prefixWriter) Flush() error {
+	if len(p.pending) == 0 {
+		return nil
+	}
+	line := append(p.pending, '\n')
+	p.pending = nil
+	return p.emit(line)
+}
+
+func (p *prefixWriter) emit(line []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := io.WriteString(p.dst, p.prefix); err != nil {
+		return err
+	}
+	_, err := p.dst.Write(line)
+	return err
+}
+
+// runLogsPrefixed runs `kubectl logs` for each matched pod and prefixes every line
+// with namespace/pod, stern-style. Without -f pods are read one after another in
+// discovery order so output is deterministic; with -f all streams run concurrently.
+// A failing pod is reported and skipped; the first error is returned at the end.
+func runLogsPrefixed(runner Runner, opts CLIOptions, matched []matchedRef, w io.Writer) error {
+	finalFlags := stripAllNamespacesFlag(stripNamespaceFlag(opts.FinalFlags))
+	follow := containsFlag(finalFlags, "-f") || containsFlag(finalFlags, "--follow") || containsFlag(finalFlags, "--follow=true") ||
+		containsFlag(opts.ExtraFinal, "-f") || containsFlag(opts.ExtraFinal, "--follow") || containsFlag(opts.ExtraFinal, "--follow=true")
+
+	var mu sync.Mutex
+	var firstErr error
+	runOne := func(m matchedRef) {
+		ns := m.ns
+		if ns == "" {
+			ns = opts.Namespace
+		}
+		args := []string{"logs", m.name}
+		if ns != "" {
+			args = append(args, "-n", ns)
+		}
+		args = append(args, finalFlags...)
+		args = append(args, opts.ExtraFinal...)
+		label := m.name
+		if ns != "" {
+			label = ns + "/" + m.name
+		}
+		prefix := label + " "
+		if !opts.NoColor {
+			prefix = colorForValue(m.name) + label + "\x1b[0m "
+		}
+		pw := &prefixWriter{mu: &mu, dst: w, prefix: prefix}
+		err := streamKubectl(runner, args, pw)
+		if ferr := pw.Flush(); ferr != nil && err == nil {
+			err = ferr
+		}
+		if err != nil {
+			mu.Lock()
+			fmt.Fprintf(os.Stderr, "logs for pod %s failed: %v\n", label, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			mu.Unlock()
+		}
+	}
+
+	if !follow {
+		for _, m := range matched {
+			runOne(m)
+		}
+		return firstErr
+	}
+	var wg sync.WaitGroup
+	for _, m := range matched {
+		wg.Add(1)
+		go func(m matchedRef) {
+			defer wg.Done()
+			runOne(m)
+		}(m)
+	}
+	wg.Wait()
+	return firstErr
+}
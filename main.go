@@ -5,8 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math/rand"
+	"net"
 	"os"
+	"path"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -15,6 +21,22 @@ import (
 type matchedRef struct {
 	ns, name string
 	labels   map[string]string
+	phase    string
+	restarts int
+	node     string
+	// container counts for the READY column
+	containers, notReady int
+	// raw discovery JSON, reused to render tables without listing again
+	raw json.RawMessage
+	// Kind/Name of ownerReferences, for --show-owner
+	owners []string
+	// pod IP, kubectl-style STATUS and creation time for --client-table
+	ip, status string
+	created    time.Time
+	// item kind (e.g. Pod), to tell apart --events about same-named objects
+	kind string
+	// pod phase and container reasons, for --json
+	reasons []string
 }
 
 // These are intended to be overridden at build time via -ldflags, e.g.:
@@ -27,59 +49,153 @@ var (
 
 func printUsage() {
 	fmt.Fprintf(os.Stderr, "Usage:\n")
-	fmt.Fprintf(os.Stderr, "  kubectl wild (get|delete|describe|top) [resource] [pattern] [flags...] [-- extra]\n\n")
+	fmt.Fprintf(os.Stderr, "  kubectl wild (get|delete|describe|top|logs|scale|rollout-restart|label|annotate|patch|wait) [resource] [pattern] [flags...] [-- extra]\n\n")
 	fmt.Fprintf(os.Stderr, "Key flags:\n")
 	fmt.Fprintf(os.Stderr, "  Matching:\n")
 	fmt.Fprintf(os.Stderr, "    --regex              Use regex matching for pattern\n")
 	fmt.Fprintf(os.Stderr, "    --contains           Use substring matching for pattern\n")
+	fmt.Fprintf(os.Stderr, "    --exact              Match names literally (no glob/regex metacharacters)\n")
 	fmt.Fprintf(os.Stderr, "    --fuzzy              Use fuzzy matching (handles hashed pod names)\n")
 	fmt.Fprintf(os.Stderr, "    --fuzzy-distance N   Max edit distance for fuzzy matching (default: 1)\n")
 	fmt.Fprintf(os.Stderr, "    --prefix/-p VAL      Match names starting with VAL\n")
 	fmt.Fprintf(os.Stderr, "    --match VAL          Add include pattern (repeatable)\n")
 	fmt.Fprintf(os.Stderr, "    --exclude VAL        Add exclude pattern (repeatable)\n")
-	fmt.Fprintf(os.Stderr, "    --ignore-case        Case-insensitive matching\n\n")
+	fmt.Fprintf(os.Stderr, "    --ignore-case        Case-insensitive matching\n")
+	fmt.Fprintf(os.Stderr, "    --suggest/--no-suggest  On no matches, print the closest name (default: on for literal patterns)\n")
+	fmt.Fprintf(os.Stderr, "    -v, --invert         Keep names NOT matching the pattern (--exclude still applies)\n")
+	fmt.Fprintf(os.Stderr, "    --full-name-match    Also match patterns against namespace/name without -A\n\n")
 	fmt.Fprintf(os.Stderr, "  Scope:\n")
 	fmt.Fprintf(os.Stderr, "    -n, --namespace NS   Target namespace (supports wildcards like 'prod-*')\n")
 	fmt.Fprintf(os.Stderr, "    -A, --all-namespaces Discover across all namespaces\n")
-	fmt.Fprintf(os.Stderr, "    --ns NS              Filter to exact namespace (repeatable)\n")
-	fmt.Fprintf(os.Stderr, "    --ns-prefix PFX      Filter namespaces by prefix (repeatable)\n")
-	fmt.Fprintf(os.Stderr, "    --ns-regex RE        Filter namespaces by regex (repeatable)\n\n")
+	fmt.Fprintf(os.Stderr, "    --ns NS              Filter to exact namespace (repeatable, or comma-separated)\n")
+	fmt.Fprintf(os.Stderr, "    --ns-prefix PFX      Filter namespaces by prefix (repeatable, or comma-separated)\n")
+	fmt.Fprintf(os.Stderr, "    --ns-regex RE        Filter namespaces by regex (repeatable)\n")
+	fmt.Fprintf(os.Stderr, "    --ns-exclude NS      Drop an exact namespace, even if included (repeatable; implies -A)\n")
+	fmt.Fprintf(os.Stderr, "    --ns-exclude-prefix PFX  Drop namespaces by prefix, e.g. kube- (repeatable; implies -A)\n")
+	fmt.Fprintf(os.Stderr, "    --kubeconfigs F1,F2  Run once per kubeconfig file (KUBECONFIG set per file), header per file on stderr\n")
+	fmt.Fprintf(os.Stderr, "    --name-collisions    With -A: keep only names present in more than one namespace\n")
+	fmt.Fprintf(os.Stderr, "    --require-namespace  Drop items missing metadata.namespace (malformed items of a namespaced resource)\n")
+	fmt.Fprintf(os.Stderr, "    --show-owner         With get -A: add a CONTROLLED-BY column from ownerReferences\n")
+	fmt.Fprintf(os.Stderr, "    --client-table       With get pods -A: render the -o wide table from discovery, without a second kubectl call\n")
+	fmt.Fprintf(os.Stderr, "    --sort-by KEY        Order matches by age|restarts|ready|name|node|namespace (other values go to kubectl)\n")
+	fmt.Fprintf(os.Stderr, "    --sort-reverse       Reverse the --sort-by order (e.g. most restarts first)\n\n")
 	fmt.Fprintf(os.Stderr, "  Labels:\n")
-	fmt.Fprintf(os.Stderr, "    --label key=glob         Filter by label value glob (repeatable)\n")
+	fmt.Fprintf(os.Stderr, "    --label key=glob         Filter by label value glob (repeatable; '!key': absent, 'key!=glob': present, not matching)\n")
 	fmt.Fprintf(os.Stderr, "    --label-prefix key=pfx   Filter by label value prefix\n")
 	fmt.Fprintf(os.Stderr, "    --label-contains key=sub Filter by label value substring\n")
 	fmt.Fprintf(os.Stderr, "    --label-regex key=re     Filter by label value regex\n")
+	fmt.Fprintf(os.Stderr, "    --label-gt key=N         Filter by integer label value (also -ge, -lt, -le, -eq)\n")
+	fmt.Fprintf(os.Stderr, "    --label-in key=a,b       Filter by label value in a set (--label-notin: not in it, or no such label)\n")
 	fmt.Fprintf(os.Stderr, "    --label-key-regex RE     Require label key matching regex\n")
-	fmt.Fprintf(os.Stderr, "    --group-by-label KEY     Add -L column and group output by label\n")
-	fmt.Fprintf(os.Stderr, "    --colorize-labels        Show colored summary when grouping\n\n")
+	fmt.Fprintf(os.Stderr, "    --has-label KEY          Require the label key, any value (--no-label: require it absent)\n")
+	fmt.Fprintf(os.Stderr, "    --group-by-label KEY     Add -L column and group output by label (repeatable for composite groups)\n")
+	fmt.Fprintf(os.Stderr, "    --colorize-labels        Show colored summary when grouping\n")
+	fmt.Fprintf(os.Stderr, "    --group-by-owner         Print match counts per owner Kind/Name to stderr (get)\n\n")
 	fmt.Fprintf(os.Stderr, "  Annotations:\n")
 	fmt.Fprintf(os.Stderr, "    --annotation key=glob         Filter by annotation value glob\n")
 	fmt.Fprintf(os.Stderr, "    --annotation-prefix key=pfx   Filter by annotation value prefix\n")
 	fmt.Fprintf(os.Stderr, "    --annotation-contains key=sub Filter by annotation value substring\n")
 	fmt.Fprintf(os.Stderr, "    --annotation-regex key=re     Filter by annotation value regex\n")
-	fmt.Fprintf(os.Stderr, "    --annotation-key-regex RE     Require annotation key matching regex\n\n")
+	fmt.Fprintf(os.Stderr, "    --annotation-gt key=N         Filter by integer annotation value (also -ge, -lt, -le, -eq)\n")
+	fmt.Fprintf(os.Stderr, "    --annotation-key-regex RE     Require annotation key matching regex\n")
+	fmt.Fprintf(os.Stderr, "    --has-annotation KEY          Require the annotation key (--no-annotation: require it absent)\n\n")
+	fmt.Fprintf(os.Stderr, "  Finalizers:\n")
+	fmt.Fprintf(os.Stderr, "    --terminating            Show objects with a deletionTimestamp (stuck Terminating)\n")
+	fmt.Fprintf(os.Stderr, "    --has-finalizers         Show objects with any metadata.finalizers\n")
+	fmt.Fprintf(os.Stderr, "    --finalizer NAME         Show objects with finalizer NAME (repeatable, any of)\n\n")
+	fmt.Fprintf(os.Stderr, "  Ownership:\n")
+	fmt.Fprintf(os.Stderr, "    --managed-by GLOB        Show objects whose managedFields include a matching manager (repeatable, any of)\n")
+	fmt.Fprintf(os.Stderr, "    --condition TYPE=STATUS  Show objects whose status.conditions TYPE has STATUS (repeatable, all of)\n")
+	fmt.Fprintf(os.Stderr, "    --node-not-ready         Nodes whose Ready condition is not True (False, Unknown or missing)\n")
+	fmt.Fprintf(os.Stderr, "    --node-memory-pressure   Nodes with MemoryPressure=True (also --node-disk-pressure, --node-pid-pressure;\n")
+	fmt.Fprintf(os.Stderr, "                             any of; --node-pressure checks all three)\n")
+	fmt.Fprintf(os.Stderr, "    --owner-kind KIND        Show objects with an ownerReference of KIND (repeatable, any of)\n")
+	fmt.Fprintf(os.Stderr, "    --owner-name GLOB        Show objects with an owner whose name matches (with --owner-kind: the same owner)\n")
+	fmt.Fprintf(os.Stderr, "    --stale-replicaset       Show ReplicaSets scaled to 0 in spec and status (old Deployment revisions)\n")
+	fmt.Fprintf(os.Stderr, "    --replicas-unready       Show Deployments/StatefulSets/ReplicaSets with readyReplicas < spec.replicas\n\n")
 	fmt.Fprintf(os.Stderr, "  Pod health:\n")
-	fmt.Fprintf(os.Stderr, "    --pod-status STATUS      Filter by pod phase/status (Running, Pending, etc.)\n")
+	fmt.Fprintf(os.Stderr, "    --status STATUS          Filter by status.phase for any resource (PVC Pending, Namespace Terminating, ...);\n")
+	fmt.Fprintf(os.Stderr, "                             for pods also container reasons (alias: --pod-status)\n")
+	fmt.Fprintf(os.Stderr, "    --evicted                Show evicted pods (same as --pod-status Evicted)\n")
 	fmt.Fprintf(os.Stderr, "    --unhealthy              Show only unhealthy pods (not clean Running/Succeeded)\n")
-	fmt.Fprintf(os.Stderr, "    --older-than DURATION    Filter pods older than duration (e.g., 1h, 7d)\n")
+	fmt.Fprintf(os.Stderr, "    --older-than DURATION    Filter pods older than duration (e.g., 1h, 7d, '3 days ago')\n")
 	fmt.Fprintf(os.Stderr, "    --younger-than DURATION  Filter pods younger than duration\n")
+	fmt.Fprintf(os.Stderr, "    --as-of TIMESTAMP        Evaluate age filters relative to an RFC3339 time instead of now\n")
 	fmt.Fprintf(os.Stderr, "    --restarts EXPR          Filter by restart count (>N, >=N, <N, <=N, =N)\n")
+	fmt.Fprintf(os.Stderr, "    --ready-containers EXPR  Filter by number of ready containers, e.g. '<2' (sidecar health)\n")
+	fmt.Fprintf(os.Stderr, "    --ordinal-range M-N  Filter StatefulSet pods by ordinal (name-M .. name-N, inclusive)\n")
+	fmt.Fprintf(os.Stderr, "    --ready-flapped-within D Pods whose Ready condition changed within duration D\n")
+	fmt.Fprintf(os.Stderr, "    --restart-delta N        With --from-snapshot: pods whose restarts grew by at least N\n")
+	fmt.Fprintf(os.Stderr, "    --from-snapshot FILE     Prior `kubectl get pods -o json` output to compare restarts against\n")
 	fmt.Fprintf(os.Stderr, "    --containers-not-ready   Show pods with not-ready containers\n")
 	fmt.Fprintf(os.Stderr, "    --reason REASON          Filter by container reason (OOMKilled, CrashLoopBackOff)\n")
-	fmt.Fprintf(os.Stderr, "    --container-name NAME    Scope reason filter to specific container\n\n")
+	fmt.Fprintf(os.Stderr, "    --container-name NAME    Scope reason filter to specific container (init:NAME for init containers only)\n")
+	fmt.Fprintf(os.Stderr, "    --churning               Show old pods still restarting recently\n")
+	fmt.Fprintf(os.Stderr, "    --churning-age DURATION  Minimum pod age / recent-restart window for --churning (default: 24h)\n")
+	fmt.Fprintf(os.Stderr, "    --churning-restarts N    Minimum restarts for --churning (default: 5)\n")
+	fmt.Fprintf(os.Stderr, "    --unscheduled            Show Pending pods not yet assigned to a node\n")
+	fmt.Fprintf(os.Stderr, "    --scheduling-gated       Show pods with spec.schedulingGates set\n\n")
 	fmt.Fprintf(os.Stderr, "  Node filters:\n")
-	fmt.Fprintf(os.Stderr, "    --node NAME          Filter pods on exact node (repeatable)\n")
+	fmt.Fprintf(os.Stderr, "    --node NAME          Filter pods on exact node (repeatable, or comma-separated)\n")
 	fmt.Fprintf(os.Stderr, "    --node-prefix PFX    Filter pods on nodes by prefix\n")
-	fmt.Fprintf(os.Stderr, "    --node-regex RE      Filter pods on nodes by regex\n\n")
+	fmt.Fprintf(os.Stderr, "    --node-regex RE      Filter pods on nodes by regex\n")
+	fmt.Fprintf(os.Stderr, "    --node-selector key=glob  Filter pods whose spec.nodeSelector matches (repeatable)\n")
+	fmt.Fprintf(os.Stderr, "    --no-node-selector   Filter pods without any spec.nodeSelector\n")
+	fmt.Fprintf(os.Stderr, "    --has-affinity       Filter pods with spec.affinity set\n")
+	fmt.Fprintf(os.Stderr, "    --no-affinity        Filter pods without spec.affinity\n")
+	fmt.Fprintf(os.Stderr, "    --tolerates KEY      Filter pods tolerating taint KEY (repeatable)\n")
+	fmt.Fprintf(os.Stderr, "    --grace-period-longer-than S  Filter pods with terminationGracePeriodSeconds above S (default 30)\n")
+	fmt.Fprintf(os.Stderr, "    --restart-policy P   Filter pods by spec.restartPolicy (Always|OnFailure|Never)\n")
+	fmt.Fprintf(os.Stderr, "    --qos CLASS          Filter pods by status.qosClass (Guaranteed|Burstable|BestEffort; repeatable)\n")
+	fmt.Fprintf(os.Stderr, "    --pod-ip CIDR|GLOB   Filter pods by status.podIP, e.g. 10.244.0.0/16 or '10.244.3.*' (repeatable)\n")
+	fmt.Fprintf(os.Stderr, "    --host-ip CIDR|GLOB  Filter pods by status.hostIP (node address; repeatable)\n")
+	fmt.Fprintf(os.Stderr, "    --no-readiness-probe Filter pods where some container has no readinessProbe\n")
+	fmt.Fprintf(os.Stderr, "    --no-liveness-probe  Filter pods where some container has no livenessProbe\n")
+	fmt.Fprintf(os.Stderr, "    --uses-pvc GLOB      Pods mounting a PVC whose claim name matches (repeatable, any of)\n")
+	fmt.Fprintf(os.Stderr, "    --uses-configmap GLOB  Pods referencing a matching ConfigMap (volume, envFrom, env valueFrom)\n")
+	fmt.Fprintf(os.Stderr, "    --uses-secret GLOB   Pods referencing a matching Secret (also projected volumes, imagePullSecrets)\n")
+	fmt.Fprintf(os.Stderr, "    --image GLOB         Pods with any (init/ephemeral) container image matching (repeatable; --image-regex RE)\n\n")
 	fmt.Fprintf(os.Stderr, "  Safety (delete):\n")
 	fmt.Fprintf(os.Stderr, "    --dry-run            Preview without deleting\n")
 	fmt.Fprintf(os.Stderr, "    --server-dry-run     Server-side dry-run\n")
 	fmt.Fprintf(os.Stderr, "    --confirm-threshold N  Block if matches > N (unless -y)\n")
+	fmt.Fprintf(os.Stderr, "    --remove-finalizers  Clear metadata.finalizers before deleting (dangerous)\n")
+	fmt.Fprintf(os.Stderr, "    --emit-revert FILE   Save matched objects to FILE before deleting (restore with kubectl apply -f)\n")
 	fmt.Fprintf(os.Stderr, "    --yes/-y             Skip confirmation prompt\n")
 	fmt.Fprintf(os.Stderr, "    --preview [list|table]  Preview format\n")
-	fmt.Fprintf(os.Stderr, "    --no-color           Disable colored output\n\n")
+	fmt.Fprintf(os.Stderr, "    --preview-limit N    Names shown by the list preview before '... and M more' (default: 50, 0 = all)\n")
+	fmt.Fprintf(os.Stderr, "    --no-color           Disable colored output (default when stdout isn't a terminal or NO_COLOR is set)\n")
+	fmt.Fprintf(os.Stderr, "    --color WHEN         always|never|auto; --color=always keeps color when piped\n\n")
+	fmt.Fprintf(os.Stderr, "  Output (get):\n")
+	fmt.Fprintf(os.Stderr, "    --metrics            Print Prometheus metrics of matches per namespace/phase instead of a table\n")
+	fmt.Fprintf(os.Stderr, "    --json               Print matches as {\"wildVersion\":\"1\",\"items\":[...]} instead of a table\n")
+	fmt.Fprintf(os.Stderr, "    --format json        Same as --json\n")
+	fmt.Fprintf(os.Stderr, "    --bare               Omit the format version wrapper/header from --json/--metrics\n")
+	fmt.Fprintf(os.Stderr, "    --count-by FIELD     Print match counts per namespace|node|phase|label:KEY (--no-headers drops the title)\n")
+	fmt.Fprintf(os.Stderr, "    --events             Print events about the matched objects (LAST SEEN, TYPE, REASON, OBJECT, MESSAGE)\n")
+	fmt.Fprintf(os.Stderr, "    --names-status       Print ns/name, phase and restarts per match (tab-separated)\n")
+	fmt.Fprintf(os.Stderr, "    --output-separator S Column separator for --names-status (default: tab)\n")
+	fmt.Fprintf(os.Stderr, "    -q, --names-only     Print matched names only (namespace/name with -A), one per line\n")
+	fmt.Fprintf(os.Stderr, "    --print0             Like --names-only but NUL-separated, for xargs -0\n")
+	fmt.Fprintf(os.Stderr, "    --error-on-empty     Exit 1 when nothing matched (for CI branching)\n")
+	fmt.Fprintf(os.Stderr, "    --sample N           Act on N randomly chosen matches (e.g. describe a few of many replicas)\n")
+	fmt.Fprintf(os.Stderr, "    --limit N            Act on the first N matches (in --sort-by order if given)\n")
+	fmt.Fprintf(os.Stderr, "    --seed S             Seed for --sample, for a reproducible pick\n\n")
+	fmt.Fprintf(os.Stderr, "  Paging (get/describe):\n")
+	fmt.Fprintf(os.Stderr, "    --pager              Page kubectl output through $PAGER (default: less -R) when stdout is a TTY\n")
+	fmt.Fprintf(os.Stderr, "    --no-pager           Never page (overrides --pager)\n\n")
+	fmt.Fprintf(os.Stderr, "  Output (describe):\n")
+	fmt.Fprintf(os.Stderr, "    --compact-describe   Strip \"Managed Fields\" sections from describe output\n\n")
+	fmt.Fprintf(os.Stderr, "  Waiting (get):\n")
+	fmt.Fprintf(os.Stderr, "    --poll-until-empty DURATION  Re-run matching until nothing matches or DURATION elapses\n")
+	fmt.Fprintf(os.Stderr, "    --poll-until-count N         Re-run matching until exactly N match (see --poll-timeout)\n")
+	fmt.Fprintf(os.Stderr, "    --poll-timeout DURATION      Timeout for --poll-until-count (default: 5m)\n\n")
 	fmt.Fprintf(os.Stderr, "  Other:\n")
 	fmt.Fprintf(os.Stderr, "    --batch-size N       Batch size for kubectl calls (default: 200)\n")
+	fmt.Fprintf(os.Stderr, "    --retry-conflict N   Retry patch/label/annotate (and --remove-finalizers) calls up to N times on 409 Conflict\n")
+	fmt.Fprintf(os.Stderr, "    --continue-on-error  Keep going past failed kubectl calls; print 'N succeeded, M failed' and exit non-zero\n")
+	fmt.Fprintf(os.Stderr, "    --max-parallel N     With -A: run non-get verbs in up to N namespaces at once (default: 1)\n")
+	fmt.Fprintf(os.Stderr, "    --selectivity        Print how many objects each filter stage kept (to stderr)\n")
 	fmt.Fprintf(os.Stderr, "    --debug              Show debug output\n")
 	fmt.Fprintf(os.Stderr, "    --version/-v         Show version\n")
 	fmt.Fprintf(os.Stderr, "    --help/-h            Show this help\n\n")
@@ -91,7 +207,13 @@ func printUsage() {
 	fmt.Fprintf(os.Stderr, "  kubectl wild get pods -A --restarts '>0'           # Restarted pods\n")
 	fmt.Fprintf(os.Stderr, "  kubectl wild get pods -A --unhealthy               # Unhealthy pods\n")
 	fmt.Fprintf(os.Stderr, "  kubectl wild top pods 'api-*' -n prod              # Resource usage\n")
+	fmt.Fprintf(os.Stderr, "  kubectl wild logs 'api-*' -n prod -- --tail=50     # Logs from each matched pod\n")
 	fmt.Fprintf(os.Stderr, "  kubectl wild delete pods -p te -n default          # Delete with confirm\n")
+	fmt.Fprintf(os.Stderr, "  kubectl wild scale deploy 'batch-*' --replicas 0 -n jobs  # Scale with confirm\n")
+	fmt.Fprintf(os.Stderr, "  kubectl wild rollout-restart deploy 'api-*' -n prod     # Restart each matched workload\n")
+	fmt.Fprintf(os.Stderr, "  kubectl wild label pods 'canary-*' tier=canary --overwrite -n web  # Label with confirm\n")
+	fmt.Fprintf(os.Stderr, "  kubectl wild patch deploy 'web-*' -n prod -- --type=merge -p '{\"spec\":{\"paused\":true}}'  # Patch each match\n")
+	fmt.Fprintf(os.Stderr, "  kubectl wild wait pods 'job-*' -n ci -- --for=condition=Ready --timeout=120s  # Wait on the matched set\n")
 }
 
 func main() {
@@ -130,18 +252,95 @@ func main() {
 
 	runner := ExecRunner{}
 	if err := runCommand(runner, opts); err != nil {
+		if errors.Is(err, errNoMatches) {
+			// "No X matched" was already printed
+			os.Exit(1)
+		}
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(exitCodeFor(err))
+	}
+}
+
+// noMatchesMessage is the "No X matched" line, with a "Did you mean" hint when the
+// pattern is a near miss of a discovered name.
+func noMatchesMessage(opts CLIOptions, found discovery) string {
+	if shouldSuggest(opts) {
+		if name := suggestName(opts, found); name != "" {
+			return fmt.Sprintf("No %s matched '%s'. Did you mean '%s'?", displayResource(opts.Resource), opts.Include[0], name)
+		}
+	}
+	return fmt.Sprintf("No %s matched given criteria.", displayResource(opts.Resource))
+}
+
+// shouldSuggest reports whether a zero-match run should look for a close name: by
+// default only for a single literal glob/exact pattern, since a typo is then likely.
+func shouldSuggest(opts CLIOptions) bool {
+	if opts.NoSuggest || len(opts.Include) != 1 || opts.Include[0] == "" || strings.Contains(opts.Include[0], "/") {
+		return false
 	}
+	if opts.Suggest {
+		return true
+	}
+	return (opts.Mode == MatchGlob || opts.Mode == MatchExact) && !strings.ContainsAny(opts.Include[0], "*?[")
+}
+
+// suggestName returns the discovered name closest to the pattern by edit distance,
+// or "" when none is within half the pattern's length. It stays quiet when the
+// pattern did match names that other filters then dropped: that's no typo.
+func suggestName(opts CLIOptions, found discovery) string {
+	if found.nameMatches > 0 {
+		return ""
+	}
+	pattern := []rune(opts.Include[0])
+	if opts.IgnoreCase {
+		pattern = []rune(strings.ToLower(opts.Include[0]))
+	}
+	maxDist := len(pattern) / 2
+	if maxDist < 1 {
+		maxDist = 1
+	}
+	best, bestDist := "", maxDist+1
+	for _, r := range found.refs {
+		name := []rune(r.Name)
+		if opts.IgnoreCase {
+			name = []rune(strings.ToLower(r.Name))
+		}
+		d := levenshtein(pattern, name)
+		if d == 0 {
+			continue
+		}
+		if d < bestDist || (d == bestDist && r.Name < best) {
+			best, bestDist = r.Name, d
+		}
+	}
+	return best
+}
+
+// errNoMatches is returned by runCommand under --error-on-empty when nothing matched.
+var errNoMatches = errors.New("no matches")
+
+// exitCoder is implemented by *exec.ExitError (and test doubles) to expose kubectl's exit status.
+type exitCoder interface {
+	ExitCode() int
+}
+
+// exitCodeFor returns kubectl's own exit code when err came from a kubectl invocation,
+// so scripts can tell failures apart (e.g., NotFound vs. auth). Defaults to 1.
+func exitCodeFor(err error) int {
+	var ec exitCoder
+	if errors.As(err, &ec) && ec.ExitCode() > 0 {
+		return ec.ExitCode()
+	}
+	return 1
 }
 
 // runVerbPassthrough passes through directly to kubectl without discovery/filtering
 func runVerbPassthrough(runner Runner, opts CLIOptions) error {
 	args := []string{string(opts.Verb), opts.Resource}
 	// Add grouping label if requested
-	if opts.Verb == VerbGet && opts.GroupByLabel != "" {
+	if opts.Verb == VerbGet && len(opts.GroupByLabel) > 0 {
 		if !containsFlag(opts.FinalFlags, "-L") && !containsFlagWithPrefix(opts.FinalFlags, "-L=") {
-			args = append(args, "-L", opts.GroupByLabel)
+			args = append(args, labelColumnFlags(opts.GroupByLabel)...)
 		}
 		if opts.ColorizeLabels {
 			// Note: can't show colored summary without discovery, but that's OK for passthrough
@@ -154,24 +353,45 @@ func runVerbPassthrough(runner Runner, opts CLIOptions) error {
 }
 
 func runCommand(runner Runner, opts CLIOptions) error {
+	if usePager(opts) {
+		p := &pagerRunner{Runner: runner}
+		opts.Pager = false
+		return p.page(runCommand(p, opts))
+	}
+	if len(opts.Kubeconfigs) > 0 {
+		return runAcrossKubeconfigs(runner, opts)
+	}
 	// Optimization: if pattern is "*" (match all) and no filters are applied, skip discovery
 	// and pass through directly to kubectl for better performance
 	// Only do this for simple cases - if there are special behaviors needed, use discovery
-	hasPattern := len(opts.Include) > 0 && !(len(opts.Include) == 1 && opts.Include[0] == "*")
-	hasFilters := len(opts.Exclude) > 0 ||
+	hasPattern := len(opts.Include) > 0 && !(len(opts.Include) == 1 && opts.Include[0] == "*" && opts.Mode != MatchExact)
+	hasFilters := len(opts.Exclude) > 0 || opts.Invert ||
 		len(opts.NsExact) > 0 || len(opts.NsPrefix) > 0 || len(opts.NsRegex) > 0 ||
+		len(opts.NsExclude) > 0 || len(opts.NsExcludePrefix) > 0 ||
 		len(opts.LabelFilters) > 0 || len(opts.LabelKeyRegex) > 0 ||
 		len(opts.AnnotationFilters) > 0 || len(opts.AnnotationKeyRegex) > 0 ||
+		len(opts.HasLabel) > 0 || len(opts.NoLabel) > 0 || len(opts.HasAnnotation) > 0 || len(opts.NoAnnotation) > 0 ||
 		len(opts.NodeExact) > 0 || len(opts.NodePrefix) > 0 || len(opts.NodeRegex) > 0 ||
 		opts.OlderThan > 0 || opts.YoungerThan > 0 ||
 		len(opts.PodStatuses) > 0 || opts.Unhealthy ||
-		opts.RestartExpr != "" || opts.ContainersNotReady || len(opts.ReasonFilters) > 0
+		opts.RestartExpr != "" || opts.ReadyContainersExpr != "" || opts.OrdinalRangeSet || opts.ContainersNotReady || len(opts.ReasonFilters) > 0 || opts.RestartDelta > 0 ||
+		opts.ReadyFlappedWithin > 0 ||
+		opts.Unscheduled || opts.SchedulingGated || opts.Churning ||
+		opts.HasFinalizers || len(opts.Finalizers) > 0 || opts.Terminating || opts.StaleReplicaSet || opts.ReplicasUnready || opts.NameCollisions || opts.RequireNamespace || len(opts.ManagedBy) > 0 ||
+		len(opts.OwnerKinds) > 0 || len(opts.OwnerNames) > 0 || len(opts.Conditions) > 0 ||
+		opts.NodeNotReady || len(opts.NodePressure) > 0 ||
+		len(opts.NodeSelectorFilters) > 0 || opts.NoNodeSelector || len(opts.Tolerates) > 0 ||
+		opts.HasAffinity || opts.NoAffinity ||
+		opts.NoReadinessProbe || opts.NoLivenessProbe || opts.GracePeriodLongerThan > 0 ||
+		opts.RestartPolicy != "" || len(opts.QOSClasses) > 0 || len(opts.PodIPs) > 0 || len(opts.HostIPs) > 0 || len(opts.UsesPVC) > 0 || len(opts.UsesConfigMap) > 0 || len(opts.UsesSecret) > 0 ||
+		len(opts.Image) > 0 || len(opts.ImageRegex) > 0
 	// Only passthrough for simple get cases: no pattern, no filters, no -A, no grouping
 	// This avoids complex behaviors that need discovery (single-table -A, cluster-scoped handling, etc.)
 	// Also skip passthrough if resource might need resolution (no dot = might be CRD shortname/singular)
 	resourceMightNeedResolution := !strings.Contains(opts.Resource, ".")
 	canPassthrough := !hasPattern && !hasFilters && opts.Verb == VerbGet &&
-		!opts.AllNamespaces && opts.GroupByLabel == "" && !resourceMightNeedResolution
+		!opts.AllNamespaces && len(opts.GroupByLabel) == 0 && !resourceMightNeedResolution && opts.PollTimeout == 0 &&
+		!opts.Metrics && !opts.JSON && !opts.NamesStatus && !opts.NamesOnly && opts.CountBy == "" && !opts.Events && !opts.ErrorOnEmpty && opts.Sample == 0 && opts.Limit == 0 && !opts.Selectivity && !opts.GroupByOwner
 	if canPassthrough {
 		// No filtering needed - pass through directly to kubectl
 		if opts.Debug {
@@ -180,24 +400,199 @@ func runCommand(runner Runner, opts CLIOptions) error {
 		return runVerbPassthrough(runner, opts)
 	}
 
+	if opts.PollTimeout > 0 {
+		return runPollUntil(runner, opts)
+	}
+	matched, found, err := discoverMatchedRefs(runner, &opts)
+	if err != nil {
+		return err
+	}
+	if opts.SortBy != "" {
+		sortMatched(matched, opts.SortBy, opts.SortReverse)
+	}
+	if opts.Limit > 0 && len(matched) > opts.Limit {
+		fmt.Fprintf(os.Stderr, "limiting to %d of %d matches\n", opts.Limit, len(matched))
+		matched = matched[:opts.Limit]
+	}
+	// Structured outputs still print their (empty) document before failing
+	var emptyErr error
+	if len(matched) == 0 && opts.ErrorOnEmpty {
+		fmt.Fprintln(os.Stderr, noMatchesMessage(opts, found))
+		emptyErr = errNoMatches
+	}
+	if opts.Metrics {
+		printMetrics(os.Stdout, opts, matched)
+		return emptyErr
+	}
+	if opts.JSON {
+		if err := printJSON(os.Stdout, matched, opts.Bare); err != nil {
+			return err
+		}
+		return emptyErr
+	}
+	if opts.NamesStatus {
+		printNamesStatus(os.Stdout, matched, opts.OutputSeparator)
+		return emptyErr
+	}
+	if opts.NamesOnly {
+		printNames(os.Stdout, matched, opts.AllNamespaces, opts.Print0)
+		return emptyErr
+	}
+	if opts.CountBy != "" {
+		printCountBy(os.Stdout, opts, matched)
+		return emptyErr
+	}
+	if len(matched) == 0 {
+		if emptyErr == nil {
+			fmt.Fprintln(os.Stderr, noMatchesMessage(opts, found))
+		}
+		return emptyErr
+	}
+
+	if opts.Events {
+		return runEvents(os.Stdout, runner, opts, matched)
+	}
+	if opts.ClientTable {
+		return printClientTable(os.Stdout, matched, opts.SortBy, opts.SortReverse, now(), !containsFlag(opts.FinalFlags, "--no-headers"))
+	}
+
+	switch opts.Verb {
+	case VerbGet:
+		// If grouping by label, add -L <key> per key for kubectl get to keep native table output.
+		// Print a colored summary ONLY when --colorize-labels is set.
+		if len(opts.GroupByLabel) > 0 {
+			if opts.ColorizeLabels {
+				printLabelSummary(os.Stderr, opts, matched)
+			}
+			if !containsFlag(opts.FinalFlags, "-L") && !containsFlagWithPrefix(opts.FinalFlags, "-L=") {
+				opts.FinalFlags = append(labelColumnFlags(opts.GroupByLabel), opts.FinalFlags...)
+			}
+		}
+		if opts.GroupByOwner {
+			printOwnerSummary(os.Stderr, opts, matched)
+		}
+		return runVerbPerScope(runner, "get", opts, matched)
+	case VerbDescribe:
+		if opts.CompactDescribe {
+			var out io.Writer = os.Stdout
+			if p, ok := runner.(*pagerRunner); ok {
+				out = &p.buf
+			}
+			return runVerbPerScope(compactDescribeRunner{Runner: runner, out: out}, "describe", opts, matched)
+		}
+		return runVerbPerScope(runner, "describe", opts, matched)
+	case VerbTop:
+		return runTopVerb(runner, opts, matched)
+	case VerbLogs:
+		if len(matched) > 1 {
+			return runLogsPrefixed(runner, opts, matched, os.Stdout)
+		}
+		return runVerbPerScope(runner, "logs", opts, matched)
+	case VerbDelete:
+		if proceed, err := guardMutation(runner, &opts, matched); err != nil || !proceed {
+			return err
+		}
+		// Back up matched objects before deleting so the delete can be reverted
+		emitRevert := opts.EmitRevert != "" && !opts.ServerDryRun
+		saved := 0
+		if emitRevert {
+			n, err := writeRevertManifest(runner, opts, matched, opts.EmitRevert)
+			if err != nil {
+				return fmt.Errorf("failed to write revert manifest, nothing deleted: %w", err)
+			}
+			saved = n
+		}
+		if opts.RemoveFinalizers {
+			if err := removeFinalizers(runner, opts, matched); err != nil {
+				return err
+			}
+		}
+		if err := runVerbPerScope(runner, "delete", opts, matched); err != nil {
+			if emitRevert {
+				fmt.Fprintf(os.Stderr, "Not every delete succeeded; %s still lists all %d matched %s\n", opts.EmitRevert, saved, displayResource(opts.Resource))
+			}
+			return err
+		}
+		if emitRevert {
+			fmt.Printf("Saved %d deleted %s to %s (restore with: kubectl apply -f %s)\n", saved, displayResource(opts.Resource), opts.EmitRevert, opts.EmitRevert)
+		}
+		return nil
+	case VerbScale:
+		// Scaling to zero is as disruptive as a delete: same threshold and confirm flow
+		if proceed, err := guardMutation(runner, &opts, matched); err != nil || !proceed {
+			return err
+		}
+		opts.FinalFlags = append(opts.FinalFlags, fmt.Sprintf("--replicas=%d", opts.Replicas))
+		return runVerbPerScope(runner, "scale", opts, matched)
+	case VerbLabel, VerbAnnotate:
+		// Relabeling can move pods out of Services and ReplicaSets: same safety flow as delete
+		if proceed, err := guardMutation(runner, &opts, matched); err != nil || !proceed {
+			return err
+		}
+		opts.FinalFlags = append(opts.FinalFlags, opts.Mutations...)
+		return runVerbPerScope(runner, string(opts.Verb), opts, matched)
+	case VerbPatch:
+		if proceed, err := guardMutation(runner, &opts, matched); err != nil || !proceed {
+			return err
+		}
+		return runVerbPerScope(runner, "patch", opts, matched)
+	case VerbWait:
+		return runVerbPerScope(runner, "wait", opts, matched)
+	case VerbRolloutRestart:
+		if opts.DryRun {
+			fmt.Printf("[dry-run] Would restart %d %s: %s\n", len(matched), displayResource(opts.Resource), strings.Join(dryRunNames(opts, matched), ", "))
+			return nil
+		}
+		if opts.ServerDryRun {
+			opts.FinalFlags = append(opts.FinalFlags, "--dry-run=server")
+		}
+		return runVerbPerScope(runner, "rollout-restart", opts, matched)
+	default:
+		return fmt.Errorf("unsupported verb: %s", opts.Verb)
+	}
+}
+
+// now is the clock used by age-based filters; overridable in tests.
+var now = time.Now
+
+// discoverMatched runs discovery for opts.Resource and applies all plugin filters.
+// opts.Resource is updated in place when it had to be resolved to a canonical CRD name.
+func discoverMatched(runner Runner, opts *CLIOptions) ([]matchedRef, error) {
+	matched, _, err := discoverMatchedRefs(runner, opts)
+	return matched, err
+}
+
+// discovery is what discoverMatchedRefs listed before filtering, kept for the
+// "Did you mean" hint so a zero-match run doesn't list the resource again.
+type discovery struct {
+	refs        []NameRef
+	nameMatches int // candidates that passed the namespace and name filters
+}
+
+// discoverMatchedRefs is discoverMatched that also returns the discovered refs.
+func discoverMatchedRefs(runner Runner, opts *CLIOptions) ([]matchedRef, discovery, error) {
 	// Try discovery first with the resource as-is - let kubectl/oc handle shortnames and common forms
 	// Only resolve to canonical if discovery fails (likely a CRD that needs resolution)
-	refs, err := discoverNames(runner, opts.Resource, opts.DiscoveryFlags)
+	discoveryFlags := append(append([]string{}, opts.DiscoveryFlags...), excludeFieldSelector(*opts)...)
+	discoveryFlags = append(discoveryFlags, labelSelectorPushdown(*opts)...)
+	// The -A single-table renderer and --emit-revert reuse the discovered JSON
+	keepRaw := opts.AllNamespaces || opts.EmitRevert != ""
+	refs, err := discoverNames(runner, opts.Resource, discoveryFlags, keepRaw)
 	if err != nil {
 		// Discovery failed - might be a CRD that needs canonical resolution
 		// Try resolving and retry discovery
-		if canon, resolveErr := resolveCanonicalResource(runner, opts.Resource); resolveErr == nil && canon != "" && canon != opts.Resource {
+		if canon, resolveErr := resolveCanonicalResource(runner, opts.Resource, connectionFlags(opts.DiscoveryFlags)); resolveErr == nil && canon != "" && canon != opts.Resource {
 			if opts.Debug {
 				fmt.Fprintf(os.Stderr, "[debug] discovery failed for %q, trying resolved form %q\n", opts.Resource, canon)
 			}
 			opts.Resource = canon
-			refs, err = discoverNames(runner, opts.Resource, opts.DiscoveryFlags)
+			refs, err = discoverNames(runner, opts.Resource, discoveryFlags, keepRaw)
 			if err != nil {
-				return err
+				return nil, discovery{}, err
 			}
 		} else {
 			// Resolution also failed or didn't change anything - return original error
-			return err
+			return nil, discovery{}, err
 		}
 	}
 	if opts.Debug {
@@ -236,6 +631,12 @@ func runCommand(runner Runner, opts CLIOptions) error {
 	for _, reStr := range opts.NodeRegex {
 		nodeRegexes = append(nodeRegexes, regexp.MustCompile(reStr))
 	}
+	// Pod-only filters apply to every spelling of the resource (pods, pod, po)
+	isPods := isPodsResource(opts.Resource)
+	imageRegexes := make([]*regexp.Regexp, 0, len(opts.ImageRegex))
+	for _, reStr := range opts.ImageRegex {
+		imageRegexes = append(imageRegexes, regexp.MustCompile(reStr))
+	}
 	// Pre-compute node exact map for fast lookup (only if many nodes)
 	var nodeExactMap map[string]bool
 	if len(opts.NodeExact) > 3 {
@@ -268,17 +669,11 @@ func runCommand(runner Runner, opts CLIOptions) error {
 	// Pre-compile label/annotation regex filters
 	labelFilters := make([]LabelFilter, len(opts.LabelFilters))
 	for i, lf := range opts.LabelFilters {
-		labelFilters[i] = lf
-		if lf.Mode == LabelRegex {
-			labelFilters[i].CompiledRegex = regexp.MustCompile(lf.Pattern)
-		}
+		labelFilters[i] = prepareLabelFilter(lf, opts.IgnoreCase)
 	}
 	annotationFilters := make([]LabelFilter, len(opts.AnnotationFilters))
 	for i, af := range opts.AnnotationFilters {
-		annotationFilters[i] = af
-		if af.Mode == LabelRegex {
-			annotationFilters[i].CompiledRegex = regexp.MustCompile(af.Pattern)
-		}
+		annotationFilters[i] = prepareLabelFilter(af, opts.IgnoreCase)
 	}
 	// Pre-compute duplicate detection for label filters (avoid allocation in hot path)
 	labelFiltersHaveDuplicates := false
@@ -340,15 +735,24 @@ func runCommand(runner Runner, opts CLIOptions) error {
 		NsPrefix:                        opts.NsPrefix,
 		NsRegex:                         nsRegexes,
 		NsExactMap:                      nsExactMap,
+		NsExclude:                       opts.NsExclude,
+		NsExcludePrefix:                 opts.NsExcludePrefix,
 		FuzzyMaxDistance:                opts.FuzzyMaxDistance,
 		LabelFilters:                    labelFilters,
 		LabelKeyRegex:                   labelKeyRegexes,
+		HasLabels:                       opts.HasLabel,
+		NoLabels:                        opts.NoLabel,
 		LabelFiltersHaveDuplicates:      labelFiltersHaveDuplicates,
 		LabelFiltersByKey:               labelFiltersByKey,
 		AnnotationFilters:               annotationFilters,
 		AnnotationKeyRegex:              annotationKeyRegexes,
+		HasAnnotations:                  opts.HasAnnotation,
+		NoAnnotations:                   opts.NoAnnotation,
 		AnnotationFiltersHaveDuplicates: annotationFiltersHaveDuplicates,
 		AnnotationFiltersByKey:          annotationFiltersByKey,
+		OwnerKinds:                      opts.OwnerKinds,
+		OwnerNames:                      opts.OwnerNames,
+		Invert:                          opts.Invert,
 	}
 	// Pre-allocate matched slice with estimated capacity (assume ~10% match rate for large lists)
 	estimatedCapacity := len(refs) / 10
@@ -363,17 +767,47 @@ func runCommand(runner Runner, opts CLIOptions) error {
 	}
 	matched := make([]matchedRef, 0, estimatedCapacity)
 	// Pre-compute if we need labels (for group-by-label or colorize)
-	needsLabels := opts.GroupByLabel != "" || opts.ColorizeLabels
+	needsLabels := len(opts.GroupByLabel) > 0 || opts.ColorizeLabels || strings.HasPrefix(opts.CountBy, "label:") || opts.JSON
+	var snapshotRestarts map[string]int
+	if opts.RestartDelta > 0 {
+		var err error
+		if snapshotRestarts, err = loadSnapshotRestarts(opts.FromSnapshot); err != nil {
+			return nil, discovery{}, err
+		}
+	}
+	// Reference time for age filters: --as-of when given, otherwise the current time
+	asOf := now()
+	if !opts.AsOf.IsZero() {
+		asOf = opts.AsOf
+	}
+	nameMatched := 0
+	var funnel *filterFunnel
+	if opts.Selectivity {
+		funnel = newFilterFunnel(len(refs))
+	}
 	for _, r := range refs {
 		// Optimize filter order: check cheapest filters first for early exit
 		// 1. Namespace filter (cheapest - simple string comparison)
 		if !matcher.NamespaceAllowed(r.Namespace) {
 			continue
 		}
+		if opts.RequireNamespace && r.Namespace == "" {
+			continue
+		}
+		funnel.pass(stageNamespace)
 		// 2. Name matching (moderate cost - pattern matching)
-		nameMatches := matcher.Matches(r.Name)
-		if !nameMatches && opts.AllNamespaces {
-			// Only compute nsname if we're doing all-namespaces matching
+		var nameMatches bool
+		if matcher.Invert {
+			fullName := ""
+			if opts.AllNamespaces || opts.FullNameMatch {
+				fullName = r.Namespace + "/" + r.Name
+			}
+			nameMatches = matcher.InvertedMatches(r.Name, fullName)
+		} else {
+			nameMatches = matcher.Matches(r.Name)
+		}
+		if !nameMatches && !matcher.Invert && (opts.AllNamespaces || opts.FullNameMatch) {
+			// Only compute nsname if we're doing all-namespaces (or forced full-name) matching
 			// Simple concatenation is faster than strings.Builder for short strings
 			nsname := r.Namespace + "/" + r.Name
 			nameMatches = matcher.Matches(nsname)
@@ -381,18 +815,54 @@ func runCommand(runner Runner, opts CLIOptions) error {
 		if !nameMatches {
 			continue
 		}
+		funnel.pass(stageName)
+		nameMatched++
 		// 3. Label filters (more expensive - map lookups and pattern matching)
 		if !matcher.LabelsAllowed(r.Labels) {
 			continue
 		}
+		funnel.pass(stageLabels)
 		// 4. Annotation filters (more expensive - map lookups and pattern matching)
 		if !matcher.AnnotationsAllowed(r.Annotations) {
 			continue
 		}
+		funnel.pass(stageAnnotations)
+		if !matcher.OwnersAllowed(r.Owners) {
+			continue
+		}
+		if opts.StaleReplicaSet && !r.ScaledToZero {
+			continue
+		}
+		if opts.ReplicasUnready && !r.ReplicasUnready {
+			continue
+		}
+		if opts.Terminating && r.DeletionTimestamp.IsZero() {
+			continue
+		}
+		// Finalizer filters
+		if opts.HasFinalizers && len(r.Finalizers) == 0 {
+			continue
+		}
+		if len(opts.Finalizers) > 0 && !finalizersMatch(r.Finalizers, opts.Finalizers) {
+			continue
+		}
+		if len(opts.ManagedBy) > 0 && !anyGlobMatch(r.Managers, opts.ManagedBy) {
+			continue
+		}
+		if len(opts.Conditions) > 0 && !conditionsMatch(r.Conditions, opts.Conditions) {
+			continue
+		}
+		if opts.NodeNotReady && strings.EqualFold(r.Conditions["Ready"], "True") {
+			continue
+		}
+		if len(opts.NodePressure) > 0 && !anyConditionTrue(r.Conditions, opts.NodePressure) {
+			continue
+		}
+		funnel.pass(stageMetadata)
 		// All basic filters passed, now check resource-specific filters
 		// Age filters
 		if opts.OlderThan > 0 || opts.YoungerThan > 0 {
-			age := time.Since(r.CreatedAt)
+			age := asOf.Sub(r.CreatedAt)
 			if opts.OlderThan > 0 && age < opts.OlderThan {
 				continue
 			}
@@ -400,14 +870,62 @@ func runCommand(runner Runner, opts CLIOptions) error {
 				continue
 			}
 		}
+		funnel.pass(stageAge)
 		// Node filters
 		if len(opts.NodeExact) > 0 || len(opts.NodePrefix) > 0 || len(nodeRegexes) > 0 {
 			if !nodeAllowedFast(r.NodeName, opts.NodeExact, nodeExactMap, opts.NodePrefix, nodeRegexes) {
 				continue
 			}
 		}
-		// Pod status filters (only when resource == pods)
-		if opts.Resource == "pods" && len(opts.PodStatuses) > 0 {
+		// Node selector filters
+		if isPods && opts.NoNodeSelector && len(r.NodeSelector) > 0 {
+			continue
+		}
+		if isPods && len(opts.NodeSelectorFilters) > 0 && !nodeSelectorMatches(r.NodeSelector, opts.NodeSelectorFilters) {
+			continue
+		}
+		if isPods && opts.HasAffinity && !r.HasAffinity {
+			continue
+		}
+		if isPods && opts.NoAffinity && r.HasAffinity {
+			continue
+		}
+		if isPods && len(opts.Tolerates) > 0 && !toleratesAll(r.Tolerations, opts.Tolerates) {
+			continue
+		}
+		funnel.pass(stageNode)
+		if isPods && opts.NoReadinessProbe && !r.MissingReadiness {
+			continue
+		}
+		if isPods && opts.NoLivenessProbe && !r.MissingLiveness {
+			continue
+		}
+		if isPods && opts.GracePeriodLongerThan > 0 && r.GracePeriodSeconds <= opts.GracePeriodLongerThan {
+			continue
+		}
+		if isPods && opts.RestartPolicy != "" && r.RestartPolicy != opts.RestartPolicy {
+			continue
+		}
+		if isPods && len(opts.UsesPVC) > 0 && !anyGlobMatch(r.PVCs, opts.UsesPVC) {
+			continue
+		}
+		if isPods && len(opts.UsesConfigMap) > 0 && !anyGlobMatch(r.ConfigMaps, opts.UsesConfigMap) {
+			continue
+		}
+		if isPods && len(opts.UsesSecret) > 0 && !anyGlobMatch(r.Secrets, opts.UsesSecret) {
+			continue
+		}
+		if isPods && (len(opts.Image) > 0 || len(imageRegexes) > 0) && !imageAllowed(r.Images, opts.Image, imageRegexes) {
+			continue
+		}
+		funnel.pass(stageSpec)
+		// Status filters for other resources: plain status.phase comparison
+		// (PVC Bound/Pending/Lost, PV Available/Released, Namespace Active/Terminating)
+		if !isPods && len(opts.PodStatuses) > 0 && !phaseMatches(r.PodPhase, opts.PodStatuses) {
+			continue
+		}
+		// Pod status filters: phase plus container reasons
+		if isPods && len(opts.PodStatuses) > 0 {
 			matchesAny := false
 			// Pre-lowercase pod phase once for comparison
 			phaseLower := ""
@@ -466,25 +984,67 @@ func runCommand(runner Runner, opts CLIOptions) error {
 				continue
 			}
 		}
+		if isPods && len(opts.QOSClasses) > 0 && !phaseMatches(r.QOSClass, opts.QOSClasses) {
+			continue
+		}
+		if len(opts.PodIPs) > 0 && !ipAllowed(r.PodIP, opts.PodIPs) {
+			continue
+		}
+		if len(opts.HostIPs) > 0 && !ipAllowed(r.HostIP, opts.HostIPs) {
+			continue
+		}
 		// Restart expression filter
-		if opts.Resource == "pods" && opts.RestartExpr != "" {
+		if isPods && opts.RestartExpr != "" {
 			if !compareIntExpr(r.TotalRestarts, opts.RestartExpr) {
 				continue
 			}
 		}
+		if isPods && opts.ReadyContainersExpr != "" && !compareIntExpr(r.ReadyContainers, opts.ReadyContainersExpr) {
+			continue
+		}
+		if isPods && opts.OrdinalRangeSet {
+			if n, ok := podOrdinal(r.Name, r.Owners); !ok || n < opts.OrdinalMin || n > opts.OrdinalMax {
+				continue
+			}
+		}
+		// Ready condition flipped recently (even if the pod is Ready now)
+		if isPods && opts.ReadyFlappedWithin > 0 {
+			if r.ReadyTransitionAt.IsZero() || asOf.Sub(r.ReadyTransitionAt) > opts.ReadyFlappedWithin {
+				continue
+			}
+		}
+		// Restart delta since the snapshot; pods missing from it are new, so all their restarts count
+		if isPods && opts.RestartDelta > 0 {
+			if r.TotalRestarts-snapshotRestarts[r.Namespace+"/"+r.Name] < opts.RestartDelta {
+				continue
+			}
+		}
 		// Containers not ready
-		if opts.Resource == "pods" && opts.ContainersNotReady {
+		if isPods && opts.ContainersNotReady {
 			if r.NotReadyContainers == 0 {
 				continue
 			}
 		}
 		// Reason filters (optionally container-scoped)
-		if opts.Resource == "pods" && len(opts.ReasonFilters) > 0 {
+		if isPods && len(opts.ReasonFilters) > 0 {
 			if !reasonsMatch(r, opts.ReasonFilters, opts.ContainerScope) {
 				continue
 			}
 		}
-		if opts.Resource == "pods" && opts.Unhealthy {
+		// Churning: old pod, many restarts, and the latest one happened recently
+		if isPods && opts.Churning && !isChurning(r, opts.ChurningAge, opts.ChurningRestarts, asOf) {
+			continue
+		}
+		// Unscheduled: Pending pods the scheduler has not placed on a node yet
+		if isPods && opts.Unscheduled {
+			if r.NodeName != "" || !strings.EqualFold(r.PodPhase, "Pending") {
+				continue
+			}
+		}
+		if isPods && opts.SchedulingGated && len(r.SchedulingGates) == 0 {
+			continue
+		}
+		if isPods && opts.Unhealthy {
 			// unhealthy: everything that is NOT clean Running and NOT Succeeded
 			// Optimize: use direct comparison first, then EqualFold if needed
 			isRunningClean := r.PodPhase == "Running" || strings.EqualFold(r.PodPhase, "Running")
@@ -507,6 +1067,7 @@ func runCommand(runner Runner, opts CLIOptions) error {
 				continue
 			}
 		}
+		funnel.pass(stageStatus)
 		// Only copy labels if needed (for group-by-label or colorize)
 		var labelsCopy map[string]string
 		if needsLabels && r.Labels != nil {
@@ -515,7 +1076,29 @@ func runCommand(runner Runner, opts CLIOptions) error {
 				labelsCopy[k] = v
 			}
 		}
-		matched = append(matched, matchedRef{ns: r.Namespace, name: r.Name, labels: labelsCopy})
+		matched = append(matched, matchedRef{ns: r.Namespace, name: r.Name, labels: labelsCopy, phase: r.PodPhase, restarts: r.TotalRestarts, node: r.NodeName,
+			containers: r.TotalContainers, notReady: r.NotReadyContainers, raw: r.Raw, owners: r.Owners,
+			ip: r.PodIP, status: r.Status, created: r.CreatedAt, kind: r.Kind, reasons: r.PodReasons})
+	}
+	if opts.AllNamespaces && !opts.RequireNamespace {
+		warnMissingNamespaces(matched)
+	}
+	if opts.NameCollisions {
+		n := len(matched)
+		matched = keepNameCollisions(matched)
+		funnel.after("name-collisions", n, len(matched))
+	}
+	if opts.Sample > 0 {
+		seed := now().UnixNano()
+		if opts.SeedSet {
+			seed = opts.Seed
+		}
+		n := len(matched)
+		matched = sampleMatched(matched, opts.Sample, seed)
+		funnel.after("sample", n, len(matched))
+	}
+	if funnel != nil {
+		funnel.print(os.Stderr, displayResource(opts.Resource))
 	}
 	if opts.Debug {
 		fmt.Fprintf(os.Stderr, "[debug] matched after filters: %d\n", len(matched))
@@ -527,77 +1110,156 @@ func runCommand(runner Runner, opts CLIOptions) error {
 			fmt.Fprintf(os.Stderr, "[debug] keep %s/%s\n", m.ns, m.name)
 		}
 	}
-	if len(matched) == 0 {
-		fmt.Fprintf(os.Stderr, "No %s matched given criteria.\n", opts.Resource)
+	return matched, discovery{refs: refs, nameMatches: nameMatched}, nil
+}
+
+// excludeFieldSelector pushes literal-name excludes down to the server as
+// `--field-selector metadata.name!=NAME,...` so they are not fetched at all. Only
+// exact names qualify (glob mode without metacharacters, or --exact), and only when
+// matching is case-sensitive; the client-side exclude still runs either way. Skipped
+// when the user passes their own field selector, which kubectl would not merge.
+func excludeFieldSelector(opts CLIOptions) []string {
+	if opts.IgnoreCase || (opts.Mode != MatchGlob && opts.Mode != MatchExact) {
 		return nil
 	}
+	if containsFlag(opts.DiscoveryFlags, "--field-selector") || containsFlagWithPrefix(opts.DiscoveryFlags, "--field-selector=") {
+		return nil
+	}
+	var sel []string
+	for _, e := range opts.Exclude {
+		if e == "" || strings.Contains(e, "/") || strings.Contains(e, ",") {
+			continue
+		}
+		if opts.Mode == MatchGlob && strings.ContainsAny(e, "*?[\\") {
+			continue
+		}
+		sel = append(sel, "metadata.name!="+e)
+	}
+	if len(sel) == 0 {
+		return nil
+	}
+	return []string{"--field-selector", strings.Join(sel, ",")}
+}
 
-	switch opts.Verb {
-	case VerbGet:
-		// If grouping by label, add -L <key> for kubectl get to keep native table output.
-		// Print a colored summary ONLY when --colorize-labels is set.
-		if opts.GroupByLabel != "" {
-			if opts.ColorizeLabels {
-				printLabelSummary(os.Stderr, opts, matched)
-			}
-			if !containsFlag(opts.FinalFlags, "-L") && !containsFlagWithPrefix(opts.FinalFlags, "-L=") {
-				opts.FinalFlags = append([]string{"-L", opts.GroupByLabel}, opts.FinalFlags...)
-			}
+var (
+	selectorLabelKey   = regexp.MustCompile(`^[A-Za-z0-9][-A-Za-z0-9_./]*$`)
+	selectorLabelValue = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+)
+
+// labelSelectorPushdown turns exact --label key=value filters into a `-l` selector
+// so the API server drops non-matching objects before they are sent. Only glob
+// filters without metacharacters on keys that appear once qualify (OR across a
+// repeated key has no plain selector form), and only case-sensitively; the
+// client-side label matcher still runs for everything. Skipped when the user
+// passes their own -l, since kubectl keeps only the last selector.
+func labelSelectorPushdown(opts CLIOptions) []string {
+	if opts.IgnoreCase {
+		return nil
+	}
+	if containsFlag(opts.DiscoveryFlags, "-l") || containsFlag(opts.DiscoveryFlags, "--selector") ||
+		containsFlagWithPrefix(opts.DiscoveryFlags, "-l=") || containsFlagWithPrefix(opts.DiscoveryFlags, "--selector=") {
+		return nil
+	}
+	perKey := map[string]int{}
+	for _, lf := range opts.LabelFilters {
+		perKey[lf.Key]++
+	}
+	var sel []string
+	for _, lf := range opts.LabelFilters {
+		if lf.Mode != LabelGlob || perKey[lf.Key] > 1 || strings.ContainsAny(lf.Pattern, "*?[\\") {
+			continue
 		}
-		return runVerbPerScope(runner, "get", opts, matched)
-	case VerbDescribe:
-		return runVerbPerScope(runner, "describe", opts, matched)
-	case VerbTop:
-		return runTopVerb(runner, opts, matched)
-	case VerbDelete:
-		// Safety: confirm threshold BEFORE any interactive prompt
-		if opts.ConfirmThreshold > 0 && len(matched) > opts.ConfirmThreshold && !opts.Yes {
-			fmt.Printf("Matched %d items which exceeds confirm threshold %d. Aborting. Use -y to force.\n", len(matched), opts.ConfirmThreshold)
-			return nil
+		if !selectorLabelKey.MatchString(lf.Key) || !selectorLabelValue.MatchString(lf.Pattern) {
+			continue
 		}
-		if !opts.Yes && !opts.DryRun {
-			previewMode := opts.Preview
-			if previewMode == "" && opts.AllNamespaces {
-				previewMode = "table"
-			}
-			if previewMode == "table" {
-				if err := previewAsTable(runner, opts, matched); err != nil {
-					return err
-				}
-			} else {
-				previewAsList(opts, matched)
-			}
-			confirmed, err := promptYesNo("Proceed? [y/N]: ")
-			if err != nil {
-				return err
-			}
-			if !confirmed {
-				fmt.Println("Aborted.")
-				return nil
-			}
+		sel = append(sel, lf.Key+"="+lf.Pattern)
+	}
+	if len(sel) == 0 {
+		return nil
+	}
+	return []string{"-l", strings.Join(sel, ",")}
+}
+
+// sampleMatched randomly picks n items (all of them when there are fewer) and
+// returns them in their original order. The same seed always picks the same items.
+func sampleMatched(matched []matchedRef, n int, seed int64) []matchedRef {
+	if n >= len(matched) {
+		return matched
+	}
+	rng := rand.New(rand.NewSource(seed))
+	idx := rng.Perm(len(matched))[:n]
+	sort.Ints(idx)
+	sample := make([]matchedRef, 0, n)
+	for _, i := range idx {
+		sample = append(sample, matched[i])
+	}
+	return sample
+}
+
+// resourceDisplayNames maps kubectl shortnames and singulars of built-in resources
+// to their plural names for user-facing messages.
+var resourceDisplayNames = map[string]string{
+	"po": "pods", "pod": "pods",
+	"svc": "services", "service": "services",
+	"deploy": "deployments", "deployment": "deployments",
+	"rs": "replicasets", "replicaset": "replicasets",
+	"sts": "statefulsets", "statefulset": "statefulsets",
+	"ds": "daemonsets", "daemonset": "daemonsets",
+	"job": "jobs", "cj": "cronjobs", "cronjob": "cronjobs",
+	"cm": "configmaps", "configmap": "configmaps", "secret": "secrets",
+	"ns": "namespaces", "namespace": "namespaces", "no": "nodes", "node": "nodes",
+	"pvc": "persistentvolumeclaims", "pv": "persistentvolumes",
+	"sa": "serviceaccounts", "ing": "ingresses", "ingress": "ingresses", "ep": "endpoints",
+	"hpa": "horizontalpodautoscalers", "netpol": "networkpolicies", "pdb": "poddisruptionbudgets",
+	"crd": "customresourcedefinitions", "ev": "events", "event": "events", "sc": "storageclasses",
+}
+
+// displayResource returns the resource as users expect to read it in messages
+// ("po" -> "pods", "bgppeers.metallb.io" -> "bgppeers"). kubectl calls keep the
+// resource exactly as given.
+func displayResource(resource string) string {
+	r := strings.ToLower(resource)
+	if name, ok := resourceDisplayNames[r]; ok {
+		return name
+	}
+	if i := strings.IndexByte(r, '.'); i > 0 {
+		return r[:i]
+	}
+	return r
+}
+
+// warnMissingNamespaces warns when some, but not all, matches lack a namespace.
+// A mix means the resource is namespaced and those items are malformed; kubectl
+// would resolve them against the current context namespace.
+func warnMissingNamespaces(matched []matchedRef) {
+	missing := 0
+	for _, m := range matched {
+		if m.ns == "" {
+			missing++
 		}
-		if opts.DryRun {
-			var preview []string
-			if opts.AllNamespaces {
-				for _, m := range matched {
-					preview = append(preview, m.ns+"/"+m.name)
-				}
-			} else {
-				for _, m := range matched {
-					preview = append(preview, m.name)
-				}
-			}
-			fmt.Printf("[dry-run] Would delete %d %s: %s\n", len(matched), opts.Resource, strings.Join(preview, ", "))
-			return nil
+	}
+	if missing > 0 && missing < len(matched) {
+		fmt.Fprintf(os.Stderr, "warning: %d matched item(s) have no namespace and resolve to the current namespace; use --require-namespace to drop them\n", missing)
+	}
+}
+
+// keepNameCollisions keeps only items whose name occurs in more than one namespace
+// of the matched set, preserving order.
+func keepNameCollisions(matched []matchedRef) []matchedRef {
+	namespaces := map[string]map[string]bool{}
+	for _, m := range matched {
+		if namespaces[m.name] == nil {
+			namespaces[m.name] = map[string]bool{}
 		}
-		// Server-side dry-run
-		if opts.ServerDryRun {
-			opts.FinalFlags = append(opts.FinalFlags, "--dry-run=server")
+		namespaces[m.name][m.ns] = true
+	}
+	kept := matched[:0]
+	for _, m := range matched {
+		if len(namespaces[m.name]) > 1 {
+			kept = append(kept, m)
 		}
-		return runVerbPerScope(runner, "delete", opts, matched)
-	default:
-		return fmt.Errorf("unsupported verb: %s", opts.Verb)
 	}
+	return kept
 }
 
 // nodeAllowedFast is an optimized version that accepts a pre-computed map for exact matches
@@ -628,7 +1290,35 @@ func nodeAllowedFast(node string, nodeExact []string, nodeExactMap map[string]bo
 			return true
 		}
 	}
-	return false
+	return false
+}
+
+// nodeSelectorMatches reports whether the pod's nodeSelector satisfies every filter.
+func nodeSelectorMatches(selector map[string]string, filters []LabelFilter) bool {
+	for _, f := range filters {
+		val, ok := selector[f.Key]
+		if !ok || !labelValueMatches(val, f) {
+			return false
+		}
+	}
+	return true
+}
+
+// toleratesAll reports whether the pod tolerates every wanted taint key.
+func toleratesAll(tolerations []string, keys []string) bool {
+	for _, k := range keys {
+		ok := false
+		for _, t := range tolerations {
+			if t == k || t == "*" {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
 }
 
 // nodeAllowed is kept for backward compatibility with tests
@@ -673,6 +1363,19 @@ func compareIntExpr(val int, expr string) bool {
 	}
 }
 
+// isChurning reports whether a pod older than age has at least restarts restarts with
+// the most recent one inside the last age window, i.e. ongoing instability rather
+// than a one-time blip long ago.
+func isChurning(r NameRef, age time.Duration, restarts int, now time.Time) bool {
+	if r.CreatedAt.IsZero() || now.Sub(r.CreatedAt) < age {
+		return false
+	}
+	if r.TotalRestarts < restarts {
+		return false
+	}
+	return !r.LastRestartAt.IsZero() && now.Sub(r.LastRestartAt) <= age
+}
+
 func reasonsMatch(r NameRef, reasons []string, container string) bool {
 	if container == "" {
 		for _, want := range reasons {
@@ -705,9 +1408,178 @@ func reasonsMatch(r NameRef, reasons []string, container string) bool {
 	return true
 }
 
-func promptYesNo(prompt string) (bool, error) {
-	// Always print confirmation prompt in bright red to draw attention
-	fmt.Print("\x1b[31;1m" + prompt + "\x1b[0m")
+// removeFinalizersPatch is the merge patch that clears all finalizers on an object.
+const removeFinalizersPatch = `{"metadata":{"finalizers":null}}`
+
+// removeFinalizers patches each matched object to drop its finalizers so a pending
+// (or subsequent) delete can complete. kubectl patch takes one object per call.
+func removeFinalizers(runner Runner, opts CLIOptions, matched []matchedRef) error {
+	for _, m := range matched {
+		args := []string{"patch", opts.Resource, m.name, "--type=merge", "-p", removeFinalizersPatch}
+		ns := m.ns
+		if ns == "" {
+			ns = opts.Namespace
+		}
+		if ns != "" {
+			args = append(args, "-n", ns)
+		}
+		if opts.ServerDryRun {
+			args = append(args, "--dry-run=server")
+		}
+		// Same cluster as the delete that follows (--context, --kubeconfig, ...)
+		args = append(args, connectionFlags(opts.DiscoveryFlags)...)
+		if err := runMutating(runner, args, opts.RetryConflict); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finalizersMatch reports whether have contains any of the wanted finalizers.
+func finalizersMatch(have []string, want []string) bool {
+	for _, w := range want {
+		for _, h := range have {
+			if h == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// loadSnapshotRestarts reads a saved `kubectl get pods -o json` list and returns
+// total restarts keyed by namespace/name.
+func loadSnapshotRestarts(path string) (map[string]int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	refs, err := parseK8sListStreaming(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	restarts := make(map[string]int, len(refs))
+	for _, r := range refs {
+		restarts[r.Namespace+"/"+r.Name] = r.TotalRestarts
+	}
+	return restarts, nil
+}
+
+// conditionsMatch reports whether every TYPE=STATUS filter holds; a missing
+// condition never matches.
+func conditionsMatch(conditions map[string]string, filters []LabelFilter) bool {
+	for _, f := range filters {
+		status, ok := conditions[f.Key]
+		if !ok || !strings.EqualFold(status, f.Pattern) {
+			return false
+		}
+	}
+	return true
+}
+
+// anyConditionTrue reports whether any of the condition types has status True.
+func anyConditionTrue(conditions map[string]string, types []string) bool {
+	for _, t := range types {
+		if strings.EqualFold(conditions[t], "True") {
+			return true
+		}
+	}
+	return false
+}
+
+// phaseMatches reports whether phase equals any of the statuses, ignoring case.
+func phaseMatches(phase string, statuses []string) bool {
+	if phase == "" {
+		return false
+	}
+	for _, s := range statuses {
+		if strings.EqualFold(phase, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// anyGlobMatch reports whether any value (field manager, PVC name, ...) matches any of the globs.
+func anyGlobMatch(values []string, globs []string) bool {
+	for _, g := range globs {
+		for _, v := range values {
+			if ok, _ := path.Match(g, v); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ipAllowed reports whether ip falls in any of the CIDRs or matches any of the
+// globs. An empty ip (not yet assigned) never matches.
+func ipAllowed(ip string, patterns []string) bool {
+	if ip == "" {
+		return false
+	}
+	parsed := net.ParseIP(ip)
+	for _, p := range patterns {
+		if strings.Contains(p, "/") {
+			if _, cidr, err := net.ParseCIDR(p); err == nil && parsed != nil && cidr.Contains(parsed) {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(p, ip); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// imageAllowed reports whether any image matches any of the globs ('*' also spans
+// '/', as in "docker.io/*") or regexes.
+func imageAllowed(images, globs []string, regexes []*regexp.Regexp) bool {
+	for _, img := range images {
+		for _, g := range globs {
+			if globMatch(g, img) {
+				return true
+			}
+		}
+		for _, re := range regexes {
+			if re.MatchString(img) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// podOrdinal returns the StatefulSet ordinal of a pod: the number after
+// "<statefulset>-" for StatefulSet-owned pods, or the trailing "-N" for pods
+// without owners. Pods owned by anything else have no ordinal.
+func podOrdinal(name string, owners []string) (int, bool) {
+	suffix := ""
+	if len(owners) == 0 {
+		i := strings.LastIndexByte(name, '-')
+		if i < 0 {
+			return 0, false
+		}
+		suffix = name[i+1:]
+	} else {
+		for _, o := range owners {
+			if sts, ok := strings.CutPrefix(o, "StatefulSet/"); ok && strings.HasPrefix(name, sts+"-") {
+				suffix = name[len(sts)+1:]
+				break
+			}
+		}
+	}
+	if suffix == "" || strings.Trim(suffix, "0123456789") != "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(suffix)
+	return n, err == nil
+}
+
+func promptYesNo(prompt string, noColor bool) (bool, error) {
+	// Print the confirmation prompt in bright red to draw attention
+	fmt.Print(colorize(prompt, true, noColor))
 	reader := bufio.NewReader(os.Stdin)
 	text, err := reader.ReadString('\n')
 	if err != nil {
@@ -841,10 +1713,27 @@ func runTopVerb(runner Runner, opts CLIOptions, matched []matchedRef) error {
 	args = append(args, targets...)
 	args = append(args, finalFlags...)
 	args = append(args, opts.ExtraFinal...)
-	return runner.RunKubectl(args)
+	err := runner.RunKubectl(args)
+	if opts.ContinueOnError {
+		res := &batchResult{}
+		res.record(len(matched), err)
+		return res.summarize()
+	}
+	return err
 }
 
 func runVerbPerScope(runner Runner, verb string, opts CLIOptions, matched []matchedRef) error {
+	if !opts.ContinueOnError || verb == "get" {
+		return runVerbInScopes(runner, verb, opts, matched, nil)
+	}
+	res := &batchResult{}
+	if err := runVerbInScopes(runner, verb, opts, matched, res); err != nil {
+		return err
+	}
+	return res.summarize()
+}
+
+func runVerbInScopes(runner Runner, verb string, opts CLIOptions, matched []matchedRef, res *batchResult) error {
 	if !opts.AllNamespaces {
 		// Build targets as names only, ensure -n <ns> propagated
 		finalFlags := opts.FinalFlags
@@ -855,7 +1744,7 @@ func runVerbPerScope(runner Runner, verb string, opts CLIOptions, matched []matc
 		for _, m := range matched {
 			targets = append(targets, m.name)
 		}
-		return runBatched(runner, verb, opts.Resource, targets, finalFlags, opts.ExtraFinal, opts.BatchSize, false)
+		return runBatched(runner, verb, opts.Resource, targets, finalFlags, opts.ExtraFinal, opts.BatchSize, opts.RetryConflict, false, res)
 	}
 	// All-namespaces
 	finalFlags := stripAllNamespacesFlag(stripNamespaceFlag(opts.FinalFlags))
@@ -864,31 +1753,65 @@ func runVerbPerScope(runner Runner, verb string, opts CLIOptions, matched []matc
 		return runGetAcrossNamespaces(runner, opts, matched)
 	}
 	// For non-get verbs, detect cluster-scoped and avoid per-namespace iteration
-	if namespaced, err := isResourceNamespaced(runner, opts.Resource); err == nil && !namespaced {
+	if namespaced, err := isResourceNamespaced(runner, opts.Resource, connectionFlags(opts.DiscoveryFlags)); err == nil && !namespaced {
 		var names []string
 		for _, m := range matched {
 			names = append(names, m.name)
 		}
-		return runBatched(runner, verb, opts.Resource, names, finalFlags, opts.ExtraFinal, opts.BatchSize, false)
+		return runBatched(runner, verb, opts.Resource, names, finalFlags, opts.ExtraFinal, opts.BatchSize, opts.RetryConflict, false, res)
 	}
 	nsToNames := map[string][]string{}
+	var namespaces []string
 	for _, m := range matched {
+		if _, seen := nsToNames[m.ns]; !seen {
+			namespaces = append(namespaces, m.ns)
+		}
 		nsToNames[m.ns] = append(nsToNames[m.ns], m.name)
 	}
+	// With --sort-by, matched is already in the requested order: act on namespaces
+	// in order of their first match and keep names in that order. Otherwise iterate
+	// namespaces (and names within them) in sorted order so output is reproducible.
+	if opts.SortBy == "" {
+		sort.Strings(namespaces)
+		for _, ns := range namespaces {
+			sort.Strings(nsToNames[ns])
+		}
+	}
+	if opts.MaxParallel > 1 && verb != "get" && len(namespaces) > 1 {
+		return runNamespacesParallel(runner, verb, opts, namespaces, nsToNames, finalFlags, res)
+	}
 	headerPrinted := false
-	for ns, names := range nsToNames {
-		flagsForNs := append([]string{"-n", ns}, finalFlags...)
-		if err := runBatched(runner, verb, opts.Resource, names, flagsForNs, opts.ExtraFinal, opts.BatchSize, headerPrinted); err != nil {
-			return err
+	var itemErr error
+	for _, ns := range namespaces {
+		if err := runBatched(runner, verb, opts.Resource, nsToNames[ns], namespaceFlags(ns, finalFlags), opts.ExtraFinal, opts.BatchSize, opts.RetryConflict, headerPrinted, res); err != nil {
+			// A failed wait or per-name call in one namespace shouldn't skip the rest
+			if !isPerNameVerb(verb) && verb != "wait" {
+				return err
+			}
+			if itemErr == nil {
+				itemErr = err
+			}
 		}
 		if verb == "get" {
 			headerPrinted = true
 		}
 	}
-	return nil
+	return itemErr
 }
 
-func runBatched(runner Runner, verb string, resource string, targets []string, finalFlags []string, extra []string, batchSize int, suppressFirstHeader bool) error {
+// namespaceFlags prepends -n ns to flags. It never passes -n "": kubectl would
+// silently pick the context namespace anyway.
+func namespaceFlags(ns string, flags []string) []string {
+	if ns == "" {
+		return flags
+	}
+	return append([]string{"-n", ns}, flags...)
+}
+
+// runBatched runs verb over targets in batches of batchSize. With res set (--continue-on-error)
+// failures are recorded there and the remaining batches still run. Calls of verbs that
+// retriesConflicts are retried up to retries times on 409 Conflict (--retry-conflict).
+func runBatched(runner Runner, verb string, resource string, targets []string, finalFlags []string, extra []string, batchSize int, retries int, suppressFirstHeader bool, res *batchResult) error {
 	// Avoid infinite loops when batchSize is unset/zero or negative
 	if batchSize <= 0 {
 		batchSize = len(targets)
@@ -896,20 +1819,55 @@ func runBatched(runner Runner, verb string, resource string, targets []string, f
 			return nil
 		}
 	}
+	var logsErr, itemErr error
 	for i := 0; i < len(targets); i += batchSize {
 		j := i + batchSize
 		if j > len(targets) {
 			j = len(targets)
 		}
 		batch := targets[i:j]
-		// Special-case logs: kubectl logs expects a single pod per invocation
+		// Special-case logs: kubectl logs expects a single pod per invocation.
+		// A pod without logs yet (or a failed container) must not abort the rest,
+		// so keep going and report the first failure at the end.
 		if verb == "logs" {
 			for _, name := range batch {
 				args := []string{verb, name}
 				args = append(args, finalFlags...)
 				args = append(args, extra...)
-				if err := runner.RunKubectl(args); err != nil {
-					return err
+				err := runner.RunKubectl(args)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "logs for pod %s failed: %v\n", name, err)
+				}
+				if res.record(1, err) {
+					continue
+				}
+				if err != nil && logsErr == nil {
+					logsErr = err
+				}
+			}
+			continue
+		}
+		// Likewise `kubectl rollout restart` and `kubectl patch` take one object per invocation
+		if isPerNameVerb(verb) {
+			kubectlVerb := strings.Fields(strings.Replace(verb, "-", " ", 1))
+			for _, name := range batch {
+				args := append(append([]string{}, kubectlVerb...), resource, name)
+				args = append(args, finalFlags...)
+				args = append(args, extra...)
+				var err error
+				if retriesConflicts(verb) {
+					err = runMutating(runner, args, retries)
+				} else {
+					err = runner.RunKubectl(args)
+				}
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "%s for %s %s failed: %v\n", strings.Join(kubectlVerb, " "), resource, name, err)
+				}
+				if res.record(1, err) {
+					continue
+				}
+				if err != nil && itemErr == nil {
+					itemErr = err
 				}
 			}
 			continue
@@ -923,11 +1881,70 @@ func runBatched(runner Runner, verb string, resource string, targets []string, f
 		}
 		args = append(args, batchFlags...)
 		args = append(args, extra...)
-		if err := runner.RunKubectl(args); err != nil {
+		var err error
+		if retriesConflicts(verb) {
+			err = runMutating(runner, args, retries)
+		} else {
+			err = runner.RunKubectl(args)
+		}
+		if res.record(len(batch), err) {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s %s for %d object(s) failed: %v\n", verb, resource, len(batch), err)
+			}
+			continue
+		}
+		// A timed-out wait says nothing about the next batch, so wait on it too
+		if err != nil && verb == "wait" {
+			fmt.Fprintf(os.Stderr, "wait for %d %s failed: %v\n", len(batch), resource, err)
+			if itemErr == nil {
+				itemErr = err
+			}
+			continue
+		}
+		if err != nil {
 			return err
 		}
 	}
-	return nil
+	if logsErr != nil {
+		return logsErr
+	}
+	return itemErr
+}
+
+// batchResult tallies objects acted on under --continue-on-error. A failed batch
+// counts all of its objects as failed: kubectl doesn't say which ones it skipped.
+type batchResult struct {
+	succeeded, failed int
+	errs              []error
+}
+
+// record adds the outcome of a call covering n objects. It reports whether the
+// result was recorded, i.e. whether the caller should carry on regardless of err.
+// A nil receiver records nothing.
+func (b *batchResult) record(n int, err error) bool {
+	if b == nil {
+		return false
+	}
+	if err != nil {
+		b.failed += n
+		b.errs = append(b.errs, err)
+	} else {
+		b.succeeded += n
+	}
+	return true
+}
+
+// summarize prints "N succeeded, M failed" to stderr and returns the collected
+// errors joined, so the exit code still reflects a partial failure.
+func (b *batchResult) summarize() error {
+	fmt.Fprintf(os.Stderr, "%d succeeded, %d failed\n", b.succeeded, b.failed)
+	return errors.Join(b.errs...)
+}
+
+// isPerNameVerb reports whether verb runs one kubectl call per object and reports
+// failures at the end rather than stopping at the first one.
+func isPerNameVerb(verb string) bool {
+	return verb == "rollout-restart" || verb == "patch"
 }
 
 func ensureAllNamespacesFlag(flags []string) []string {
@@ -943,13 +1960,13 @@ func ensureAllNamespacesFlag(flags []string) []string {
 // so kubectl includes the NAMESPACE column.
 func runGetAcrossNamespaces(runner Runner, opts CLIOptions, matched []matchedRef) error {
 	// For cluster-scoped resources, avoid -A and fall back to normal batched get
-	if namespaced, err := isResourceNamespaced(runner, opts.Resource); err == nil && !namespaced {
+	if namespaced, err := isResourceNamespaced(runner, opts.Resource, connectionFlags(opts.DiscoveryFlags)); err == nil && !namespaced {
 		finalFlags := stripAllNamespacesFlag(stripNamespaceFlag(opts.FinalFlags))
 		var names []string
 		for _, m := range matched {
 			names = append(names, m.name)
 		}
-		return runBatched(runner, "get", opts.Resource, names, finalFlags, opts.ExtraFinal, opts.BatchSize, false)
+		return runBatched(runner, "get", opts.Resource, names, finalFlags, opts.ExtraFinal, opts.BatchSize, 0, false, nil)
 	}
 	// Use filtered List approach to let kubectl render a single table with NAMESPACE
 	finalFlags := stripAllNamespacesFlag(stripNamespaceFlag(opts.FinalFlags))
@@ -957,9 +1974,25 @@ func runGetAcrossNamespaces(runner Runner, opts CLIOptions, matched []matchedRef
 	return runGetAllNamespacesSingleTable(runner, opts, matched, finalFlags)
 }
 
-// runGetAllNamespacesSingleTable combines results into a single kubectl table by
-// filtering a JSON list and invoking kubectl once with -f.
-func runGetAllNamespacesSingleTable(runner Runner, opts CLIOptions, matched []matchedRef, finalFlags []string) error {
+type keptItem struct {
+	ns, name string
+	raw      json.RawMessage
+}
+
+// keptRawItems returns the JSON of the matched objects. Discovery already holds
+// every item, so the list is only fetched again when a match lacks its raw JSON.
+func keptRawItems(runner Runner, opts CLIOptions, matched []matchedRef) ([]keptItem, error) {
+	kept := make([]keptItem, 0, len(matched))
+	for _, m := range matched {
+		if len(m.raw) == 0 {
+			return fetchKeptItems(runner, opts, matched)
+		}
+		kept = append(kept, keptItem{ns: m.ns, name: m.name, raw: m.raw})
+	}
+	return kept, nil
+}
+
+func fetchKeptItems(runner Runner, opts CLIOptions, matched []matchedRef) ([]keptItem, error) {
 	// Build keep set keyed by ns -> name
 	keep := map[string]map[string]bool{}
 	for _, m := range matched {
@@ -973,9 +2006,9 @@ func runGetAllNamespacesSingleTable(runner Runner, opts CLIOptions, matched []ma
 	out, errOut, err := runner.CaptureKubectl(args)
 	if err != nil {
 		if len(errOut) > 0 {
-			return errors.New(strings.TrimSpace(string(errOut)))
+			return nil, errors.New(strings.TrimSpace(string(errOut)))
 		}
-		return err
+		return nil, err
 	}
 	type metaOnly struct {
 		Metadata struct {
@@ -988,17 +2021,51 @@ func runGetAllNamespacesSingleTable(runner Runner, opts CLIOptions, matched []ma
 	}
 	var lr listRaw
 	if err := json.Unmarshal(out, &lr); err != nil {
-		return fmt.Errorf("failed to parse kubectl json output: %w", err)
+		return nil, fmt.Errorf("failed to parse kubectl json output: %w", err)
 	}
-	var filtered []json.RawMessage
+	var kept []keptItem
 	for _, item := range lr.Items {
 		var mo metaOnly
 		if err := json.Unmarshal(item, &mo); err != nil {
 			continue
 		}
 		if keep[mo.Metadata.Namespace][mo.Metadata.Name] {
-			filtered = append(filtered, item)
+			kept = append(kept, keptItem{ns: mo.Metadata.Namespace, name: mo.Metadata.Name, raw: item})
+			// Keep each ns/name once, like the raw discovery path does
+			keep[mo.Metadata.Namespace][mo.Metadata.Name] = false
+		}
+	}
+	return kept, nil
+}
+
+// runGetAllNamespacesSingleTable combines results into a single kubectl table by
+// filtering a JSON list and invoking kubectl once with -f.
+func runGetAllNamespacesSingleTable(runner Runner, opts CLIOptions, matched []matchedRef, finalFlags []string) error {
+	kept, err := keptRawItems(runner, opts, matched)
+	if err != nil {
+		return err
+	}
+	// kubectl prints items in list order: keep the --sort-by order of matched, else
+	// sort by namespace/name for reproducible output
+	if opts.SortBy != "" {
+		pos := make(map[string]int, len(matched))
+		for i, m := range matched {
+			pos[m.ns+"/"+m.name] = i
 		}
+		sort.SliceStable(kept, func(i, j int) bool {
+			return pos[kept[i].ns+"/"+kept[i].name] < pos[kept[j].ns+"/"+kept[j].name]
+		})
+	} else {
+		sort.Slice(kept, func(i, j int) bool {
+			if kept[i].ns != kept[j].ns {
+				return kept[i].ns < kept[j].ns
+			}
+			return kept[i].name < kept[j].name
+		})
+	}
+	filtered := make([]json.RawMessage, 0, len(kept))
+	for _, k := range kept {
+		filtered = append(filtered, k.raw)
 	}
 	list := struct {
 		APIVersion string            `json:"apiVersion"`
@@ -1025,9 +2092,66 @@ func runGetAllNamespacesSingleTable(runner Runner, opts CLIOptions, matched []ma
 	callArgs := []string{"get", "-f", tmp.Name()}
 	callArgs = append(callArgs, finalFlags...)
 	callArgs = append(callArgs, opts.ExtraFinal...)
+	if opts.ShowOwner {
+		owners := map[string][]string{}
+		for _, m := range matched {
+			owners[m.ns+"/"+m.name] = m.owners
+		}
+		rows := make([][]string, len(kept))
+		for i, k := range kept {
+			rows[i] = owners[k.ns+"/"+k.name]
+		}
+		return writeOwnerTable(os.Stdout, runner, callArgs, rows, !containsFlag(finalFlags, "--no-headers"))
+	}
 	return runner.RunKubectl(callArgs)
 }
 
+// writeOwnerTable captures kubectl's table and appends a CONTROLLED-BY column.
+// kubectl prints the rows of a List in item order, so rows[i] belongs to line i.
+func writeOwnerTable(w io.Writer, runner Runner, callArgs []string, rows [][]string, headers bool) error {
+	out, errOut, err := runner.CaptureKubectl(callArgs)
+	if len(errOut) > 0 {
+		os.Stderr.Write(errOut)
+	}
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+	cells := make([]string, 0, len(rows)+1)
+	if headers {
+		cells = append(cells, "CONTROLLED-BY")
+	}
+	for _, owners := range rows {
+		if len(owners) == 0 {
+			cells = append(cells, "<none>")
+		} else {
+			cells = append(cells, strings.Join(owners, ","))
+		}
+	}
+	if len(lines) != len(cells) {
+		// Unexpected shape (e.g. "No resources found"): print kubectl's output untouched
+		_, err := w.Write(out)
+		return err
+	}
+	width := 0
+	for _, l := range lines {
+		if len(l) > width {
+			width = len(l)
+		}
+	}
+	for i, l := range lines {
+		// kubectl separates columns with at least three spaces
+		fmt.Fprintf(w, "%-*s   %s\n", width, l, cells[i])
+	}
+	return nil
+}
+
+// colorByDefault reports whether to color output absent --no-color/--color: only
+// when stdout is a terminal and NO_COLOR (https://no-color.org) is unset or empty.
+func colorByDefault() bool {
+	return os.Getenv("NO_COLOR") == "" && stdoutIsTerminal()
+}
+
 func colorize(s string, red bool, noColor bool) string {
 	if noColor {
 		return s
@@ -1067,37 +2191,217 @@ func colorForValue(val string) string {
 	return "\x1b[" + colors[idx] + ";1m"
 }
 
+// printOwnerSummary counts matches by their ownerReferences (Kind/Name, prefixed
+// with the namespace since owners are namespaced), largest first. Objects without
+// owners are counted as "(orphan)".
+func printOwnerSummary(w io.Writer, opts CLIOptions, matched []matchedRef) {
+	groups := map[string]int{}
+	for _, m := range matched {
+		owner := "(orphan)"
+		if len(m.owners) > 0 {
+			owner = strings.Join(m.owners, ", ")
+		}
+		if m.ns != "" {
+			owner = m.ns + "/" + owner
+		}
+		groups[owner]++
+	}
+	fmt.Fprintln(w, "Grouping by owner:")
+	printGroupCounts(w, groups, " → ", false, true)
+}
+
+// labelColumnFlags returns a kubectl -L flag per --group-by-label key.
+func labelColumnFlags(keys []string) []string {
+	flags := make([]string, 0, 2*len(keys))
+	for _, k := range keys {
+		flags = append(flags, "-L", k)
+	}
+	return flags
+}
+
+// labelGroupKey is the summary group of m: the label value for a single key, or
+// "k1=v1, k2=v2" when grouping by several keys (missing labels show as "(none)").
+func labelGroupKey(labels map[string]string, keys []string) string {
+	if len(keys) == 1 {
+		return labels[keys[0]]
+	}
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		v, ok := labels[k]
+		if !ok || v == "" {
+			v = "(none)"
+		}
+		parts[i] = k + "=" + v
+	}
+	return strings.Join(parts, ", ")
+}
+
 func printLabelSummary(w *os.File, opts CLIOptions, matched []matchedRef) {
-	key := opts.GroupByLabel
+	keys := opts.GroupByLabel
 	groups := map[string]int{}
 	for _, m := range matched {
 		if m.labels == nil {
 			continue
 		}
-		val := m.labels[key]
-		groups[val] = groups[val] + 1
+		groups[labelGroupKey(m.labels, keys)]++
 	}
 	// Print summary to stderr so table output remains clean when piped
-	fmt.Fprintf(w, "Grouping by label %s:\n", key)
-	for val, count := range groups {
-		labelText := val
-		if labelText == "" {
-			labelText = "(none)"
-		}
-		if opts.ColorizeLabels && !opts.NoColor {
-			c := colorForValue(labelText)
-			fmt.Fprintf(w, "%s%s\x1b[0m → %d\n", c, labelText, count)
+	if len(keys) == 1 {
+		fmt.Fprintf(w, "Grouping by label %s:\n", keys[0])
+	} else {
+		fmt.Fprintf(w, "Grouping by labels %s:\n", strings.Join(keys, ", "))
+	}
+	// Largest groups first, so the summary reads like a top list
+	printGroupCounts(w, groups, " → ", opts.ColorizeLabels && !opts.NoColor, true)
+	fmt.Fprintf(w, "Added %s to kubectl output.\n", strings.Join(labelColumnFlags(keys), " "))
+}
+
+// printGroupCounts renders one "value<sep>count" line per group, sorted by value,
+// or by count descending (ties by value) with byCount. Empty values are shown as "(none)".
+func printGroupCounts(w io.Writer, groups map[string]int, sep string, color, byCount bool) {
+	vals := make([]string, 0, len(groups))
+	for val := range groups {
+		vals = append(vals, val)
+	}
+	sort.Slice(vals, func(i, j int) bool {
+		if byCount && groups[vals[i]] != groups[vals[j]] {
+			return groups[vals[i]] > groups[vals[j]]
+		}
+		return vals[i] < vals[j]
+	})
+	for _, val := range vals {
+		text := val
+		if text == "" {
+			text = "(none)"
+		}
+		if color {
+			fmt.Fprintf(w, "%s%s\x1b[0m%s%d\n", colorForValue(text), text, sep, groups[val])
 		} else {
-			fmt.Fprintf(w, "%s → %d\n", labelText, count)
+			fmt.Fprintf(w, "%s%s%d\n", text, sep, groups[val])
 		}
 	}
-	fmt.Fprintf(w, "Added -L %s to kubectl output.\n", key)
 }
 
-func previewAsList(opts CLIOptions, matched []matchedRef) {
-	// Columnar list: single-ns => NAME; all-ns => NAMESPACE\tRESOURCE/NAME (bright red)
-	fmt.Printf("About to delete %d %s:\n", len(matched), opts.Resource)
+// printCountBy prints matched items counted by opts.CountBy (namespace, node, phase
+// or label:KEY) as "value: count" lines. --no-headers drops the title line.
+func printCountBy(w io.Writer, opts CLIOptions, matched []matchedRef) {
+	groups := map[string]int{}
+	for _, m := range matched {
+		var val string
+		switch opts.CountBy {
+		case "namespace":
+			val = m.ns
+		case "node":
+			val = m.node
+		case "phase":
+			val = m.phase
+		default:
+			val = m.labels[strings.TrimPrefix(opts.CountBy, "label:")]
+		}
+		groups[val]++
+	}
+	if !containsFlag(opts.FinalFlags, "--no-headers") && !containsFlag(opts.FinalFlags, "--no-headers=true") {
+		fmt.Fprintf(w, "Count by %s:\n", opts.CountBy)
+	}
+	printGroupCounts(w, groups, ": ", false, false)
+}
+
+// guardMutation runs the safety flow shared by the mutating verbs: the
+// --confirm-threshold check (before any prompt), confirmation, and --dry-run.
+// It reports whether the verb should go ahead; with --server-dry-run it adds
+// --dry-run=server to opts.FinalFlags.
+func guardMutation(runner Runner, opts *CLIOptions, matched []matchedRef) (bool, error) {
+	if opts.ConfirmThreshold > 0 && len(matched) > opts.ConfirmThreshold && !opts.Yes {
+		fmt.Printf("Matched %d items which exceeds confirm threshold %d. Aborting. Use -y to force.\n", len(matched), opts.ConfirmThreshold)
+		return false, nil
+	}
+	if opts.RemoveFinalizers {
+		fmt.Fprintln(os.Stderr, colorize("WARNING: --remove-finalizers clears metadata.finalizers before deleting.", true, opts.NoColor))
+		fmt.Fprintln(os.Stderr, colorize("Controllers will NOT get a chance to clean up external state (volumes, load balancers, DNS, ...).", true, opts.NoColor))
+	}
+	if confirmed, err := confirmMatched(runner, *opts, matched); err != nil || !confirmed {
+		return false, err
+	}
+	if opts.DryRun {
+		preview := strings.Join(dryRunNames(*opts, matched), ", ")
+		resource := displayResource(opts.Resource)
+		switch opts.Verb {
+		case VerbDelete:
+			if opts.RemoveFinalizers {
+				fmt.Printf("[dry-run] Would remove finalizers from %d %s: %s\n", len(matched), resource, preview)
+			}
+			fmt.Printf("[dry-run] Would delete %d %s: %s\n", len(matched), resource, preview)
+		case VerbScale:
+			fmt.Printf("[dry-run] Would scale %d %s to %d replicas: %s\n", len(matched), resource, opts.Replicas, preview)
+		case VerbLabel, VerbAnnotate:
+			fmt.Printf("[dry-run] Would %s %d %s with %s: %s\n", opts.Verb, len(matched), resource, strings.Join(opts.Mutations, " "), preview)
+		default:
+			fmt.Printf("[dry-run] Would %s %d %s: %s\n", opts.Verb, len(matched), resource, preview)
+		}
+		return false, nil
+	}
+	if opts.ServerDryRun {
+		opts.FinalFlags = append(opts.FinalFlags, "--dry-run=server")
+	}
+	return true, nil
+}
+
+// confirmMatched previews the matches and asks before a destructive verb runs.
+// It reports whether to go ahead; -y and --dry-run skip the prompt.
+func confirmMatched(runner Runner, opts CLIOptions, matched []matchedRef) (bool, error) {
+	if opts.Yes || opts.DryRun {
+		return true, nil
+	}
+	previewMode := opts.Preview
+	if previewMode == "" && opts.AllNamespaces {
+		previewMode = "table"
+	}
+	if previewMode == "table" {
+		if err := previewAsTable(runner, opts, matched); err != nil {
+			return false, err
+		}
+	} else {
+		previewAsList(os.Stdout, opts, matched)
+	}
+	confirmed, err := promptYesNo("Proceed? [y/N]: ", opts.NoColor)
+	if err != nil {
+		return false, err
+	}
+	if !confirmed {
+		fmt.Println("Aborted.")
+	}
+	return confirmed, nil
+}
+
+// dryRunNames lists matches for --dry-run messages: ns/name with -A, else name.
+func dryRunNames(opts CLIOptions, matched []matchedRef) []string {
+	names := make([]string, 0, len(matched))
 	for _, m := range matched {
+		if opts.AllNamespaces {
+			names = append(names, m.ns+"/"+m.name)
+		} else {
+			names = append(names, m.name)
+		}
+	}
+	return names
+}
+
+func previewAsList(w io.Writer, opts CLIOptions, matched []matchedRef) {
+	// Columnar list: single-ns => NAME; all-ns => NAMESPACE\tRESOURCE/NAME (bright red)
+	if opts.Verb == VerbScale {
+		fmt.Fprintf(w, "About to scale %d %s to %d replicas:\n", len(matched), displayResource(opts.Resource), opts.Replicas)
+	} else if isMetadataVerb(opts.Verb) {
+		fmt.Fprintf(w, "About to %s %d %s with %s:\n", opts.Verb, len(matched), displayResource(opts.Resource), strings.Join(opts.Mutations, " "))
+	} else if opts.Verb == VerbPatch {
+		fmt.Fprintf(w, "About to patch %d %s:\n", len(matched), displayResource(opts.Resource))
+	} else {
+		fmt.Fprintf(w, "About to delete %d %s:\n", len(matched), displayResource(opts.Resource))
+	}
+	shown := matched
+	if opts.PreviewLimit > 0 && len(shown) > opts.PreviewLimit {
+		shown = shown[:opts.PreviewLimit]
+	}
+	for _, m := range shown {
 		ns := m.ns
 		if ns == "" {
 			ns = opts.Namespace
@@ -1111,7 +2415,10 @@ func previewAsList(opts CLIOptions, matched []matchedRef) {
 		} else {
 			entry = m.name
 		}
-		fmt.Println(colorize(entry, true, opts.NoColor))
+		fmt.Fprintln(w, colorize(entry, true, opts.NoColor))
+	}
+	if rest := len(matched) - len(shown); rest > 0 {
+		fmt.Fprintf(w, "... and %d more\n", rest)
 	}
 }
 
@@ -1129,5 +2436,5 @@ func previewAsTable(runner Runner, opts CLIOptions, matched []matchedRef) error
 	for _, m := range matched {
 		names = append(names, m.name)
 	}
-	return runBatched(runner, "get", opts.Resource, names, finalFlags, opts.ExtraFinal, opts.BatchSize, false)
+	return runBatched(runner, "get", opts.Resource, names, finalFlags, opts.ExtraFinal, opts.BatchSize, 0, false, nil)
 }
@@ -1,12 +1,17 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -446,7 +451,7 @@ func TestParseArgs_PluginFlags(t *testing.T) {
 func TestDiscover_ErrorSurface(t *testing.T) {
 	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
 	fr.errs["get pods -o json"] = errors.New("boom")
-	_, err := discoverNames(fr, "pods", nil)
+	_, err := discoverNames(fr, "pods", nil, false)
 	if err == nil || !strings.Contains(err.Error(), "boom") {
 		t.Fatal("expected surfaced error")
 	}
@@ -683,7 +688,7 @@ func TestGroupByLabel_AddsColumn(t *testing.T) {
 		"\"metadata\":{\"name\":\"a\",\"namespace\":\"ns\",\"labels\":{\"app\":\"x\"}}}]}"
 	fr.outputs["get pods -o json"] = json
 	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"*"}, Mode: MatchGlob}
-	opts.GroupByLabel = "app"
+	opts.GroupByLabel = []string{"app"}
 	if err := runCommand(fr, opts); err != nil {
 		t.Fatal(err)
 	}
@@ -1153,7 +1158,7 @@ func TestCanonicalResource_ResolvesCRD_FromSingularAndShortname(t *testing.T) {
 func TestResolveCanonical_PassThroughGroupQualified(t *testing.T) {
 	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
 	fr.outputs["api-resources -o name --verbs=list"] = "bgppeers.metallb.io\nservices\n"
-	got, err := resolveCanonicalResource(fr, "bgppeers.metallb.io")
+	got, err := resolveCanonicalResource(fr, "bgppeers.metallb.io", nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1166,7 +1171,7 @@ func TestIsResourceNamespaced_NamespacedTrue(t *testing.T) {
 	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
 	fr.outputs["api-resources -o name --verbs=list --namespaced=true"] = "configmaps\n"
 	fr.outputs["api-resources -o name --verbs=list --namespaced=false"] = "nodes\n"
-	ns, err := isResourceNamespaced(fr, "configmaps")
+	ns, err := isResourceNamespaced(fr, "configmaps", nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1204,7 +1209,7 @@ func TestFilterAndStripNamespaceFlags(t *testing.T) {
 	}
 }
 
-func TestPrintLabelSummary_WritesCounts(t *testing.T) {
+func TestPrintLabelSummary_SortedByCount(t *testing.T) {
 	tmp, err := os.CreateTemp("", "wild-summary-*.txt")
 	if err != nil {
 		t.Fatal(err)
@@ -1214,17 +1219,22 @@ func TestPrintLabelSummary_WritesCounts(t *testing.T) {
 		{ns: "ns1", name: "a", labels: map[string]string{"app": "web"}},
 		{ns: "ns1", name: "b", labels: map[string]string{"app": "web"}},
 		{ns: "ns2", name: "c", labels: map[string]string{"app": "api"}},
+		{ns: "ns2", name: "d", labels: map[string]string{"app": "db"}},
+		{ns: "ns2", name: "e", labels: map[string]string{"app": "web"}},
+		{ns: "ns3", name: "f", labels: map[string]string{"app": "db"}},
+		{ns: "ns3", name: "g", labels: map[string]string{"team": "x"}},
 	}
-	opts := CLIOptions{GroupByLabel: "app", ColorizeLabels: false}
+	opts := CLIOptions{GroupByLabel: []string{"app"}, ColorizeLabels: false}
 	printLabelSummary(tmp, opts, matched)
 	tmp.Close()
 	data, err := os.ReadFile(tmp.Name())
 	if err != nil {
 		t.Fatal(err)
 	}
-	s := string(data)
-	if !strings.Contains(s, "web → 2") || !strings.Contains(s, "api → 1") {
-		t.Fatalf("unexpected summary: %s", s)
+	// Count descending; ties (api/(none) at 1) by label value
+	want := "Grouping by label app:\nweb → 3\ndb → 2\n(none) → 1\napi → 1\nAdded -L app to kubectl output.\n"
+	if s := string(data); s != want {
+		t.Fatalf("unexpected summary:\n%s\nwant:\n%s", s, want)
 	}
 }
 
@@ -1353,7 +1363,7 @@ func TestFilterOutputFlags_EqualsForms(t *testing.T) {
 
 func TestRunBatched_Logs(t *testing.T) {
 	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
-	if err := runBatched(fr, "logs", "pods", []string{"p1", "p2"}, nil, nil, 10, false); err != nil {
+	if err := runBatched(fr, "logs", "pods", []string{"p1", "p2"}, nil, nil, 10, 0, false, nil); err != nil {
 		t.Fatal(err)
 	}
 	// Expect two calls: logs p1 and logs p2
@@ -1442,7 +1452,7 @@ func TestRunVerbPassthrough(t *testing.T) {
 
 	// Test with GroupByLabel
 	fr.calls = [][]string{}
-	opts.GroupByLabel = "app"
+	opts.GroupByLabel = []string{"app"}
 	opts.FinalFlags = []string{"-o", "wide"}
 	if err := runVerbPassthrough(fr, opts); err != nil {
 		t.Fatal(err)
@@ -1545,7 +1555,7 @@ func TestResourceScopeCache_Used(t *testing.T) {
 	// Seed cache and verify lookup does not require runner outputs
 	resourceScopeCache[strings.ToLower("crd.example.com")] = false
 	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
-	ns, err := isResourceNamespaced(fr, "crd.example.com")
+	ns, err := isResourceNamespaced(fr, "crd.example.com", nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1557,7 +1567,7 @@ func TestResourceScopeCache_Used(t *testing.T) {
 func TestResourceCanonicalCache_Used(t *testing.T) {
 	resourceCanonicalCache[strings.ToLower("foo")] = "things.example.com"
 	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
-	got, err := resolveCanonicalResource(fr, "foo")
+	got, err := resolveCanonicalResource(fr, "foo", nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1629,6 +1639,12 @@ func TestAllCLIFlags(t *testing.T) {
 			}
 			return nil
 		}},
+		{"--exact", []string{"get", "pods", "web[0]", "--exact"}, func(o CLIOptions) error {
+			if o.Mode != MatchExact || len(o.Include) != 1 || o.Include[0] != "web[0]" {
+				return fmt.Errorf("expected Mode=MatchExact with include web[0], got %v %v", o.Mode, o.Include)
+			}
+			return nil
+		}},
 		{"--fuzzy", []string{"get", "pods", "api", "--fuzzy"}, func(o CLIOptions) error {
 			if o.Mode != MatchFuzzy {
 				return fmt.Errorf("expected Mode=MatchFuzzy, got %v", o.Mode)
@@ -1671,6 +1687,12 @@ func TestAllCLIFlags(t *testing.T) {
 			}
 			return nil
 		}},
+		{"--full-name-match", []string{"get", "pods", "prod/web-*", "--full-name-match"}, func(o CLIOptions) error {
+			if !o.FullNameMatch {
+				return fmt.Errorf("expected FullNameMatch=true")
+			}
+			return nil
+		}},
 
 		// SCOPE FLAGS
 		{"-n (namespace)", []string{"get", "pods", "x*", "-n", "default"}, func(o CLIOptions) error {
@@ -1769,8 +1791,8 @@ func TestAllCLIFlags(t *testing.T) {
 			return nil
 		}},
 		{"--group-by-label", []string{"get", "pods", "*", "--group-by-label", "app", "-A"}, func(o CLIOptions) error {
-			if o.GroupByLabel != "app" {
-				return fmt.Errorf("expected GroupByLabel=app, got %v", o.GroupByLabel)
+			if !reflect.DeepEqual(o.GroupByLabel, []string{"app"}) {
+				return fmt.Errorf("expected GroupByLabel=[app], got %v", o.GroupByLabel)
 			}
 			return nil
 		}},
@@ -1826,6 +1848,320 @@ func TestAllCLIFlags(t *testing.T) {
 			}
 			return nil
 		}},
+		{"--name-collisions", []string{"get", "svc", "*", "-A", "--name-collisions"}, func(o CLIOptions) error {
+			if !o.NameCollisions || !o.AllNamespaces {
+				return fmt.Errorf("expected NameCollisions with -A, got %v %v", o.NameCollisions, o.AllNamespaces)
+			}
+			return nil
+		}},
+		{"--evicted", []string{"delete", "pods", "-A", "--evicted", "-y"}, func(o CLIOptions) error {
+			if len(o.PodStatuses) != 1 || o.PodStatuses[0] != "Evicted" {
+				return fmt.Errorf("expected PodStatuses=[Evicted], got %v", o.PodStatuses)
+			}
+			return nil
+		}},
+		{"--terminating", []string{"get", "pods", "*", "--terminating"}, func(o CLIOptions) error {
+			if !o.Terminating {
+				return fmt.Errorf("expected Terminating=true")
+			}
+			return nil
+		}},
+		{"--managed-by", []string{"get", "deploy", "*", "--managed-by", "argocd*", "--managed-by", "helm"}, func(o CLIOptions) error {
+			if len(o.ManagedBy) != 2 || o.ManagedBy[0] != "argocd*" || o.ManagedBy[1] != "helm" {
+				return fmt.Errorf("expected ManagedBy=[argocd* helm], got %v", o.ManagedBy)
+			}
+			return nil
+		}},
+		{"--ready-flapped-within", []string{"get", "pods", "*", "--ready-flapped-within", "10m"}, func(o CLIOptions) error {
+			if o.ReadyFlappedWithin != 10*time.Minute {
+				return fmt.Errorf("expected ReadyFlappedWithin=10m, got %v", o.ReadyFlappedWithin)
+			}
+			return nil
+		}},
+		{"--restart-delta", []string{"get", "pods", "*", "--restart-delta", "2", "--from-snapshot", "before.json"}, func(o CLIOptions) error {
+			if o.RestartDelta != 2 || o.FromSnapshot != "before.json" {
+				return fmt.Errorf("expected RestartDelta=2 FromSnapshot=before.json, got %d %q", o.RestartDelta, o.FromSnapshot)
+			}
+			return nil
+		}},
+		{"--status", []string{"get", "pvc", "*", "--status", "Pending"}, func(o CLIOptions) error {
+			if !reflect.DeepEqual(o.PodStatuses, []string{"Pending"}) {
+				return fmt.Errorf("expected PodStatuses=[Pending], got %v", o.PodStatuses)
+			}
+			return nil
+		}},
+		{"rollout-restart", []string{"rollout-restart", "sts", "db-*", "-n", "data"}, func(o CLIOptions) error {
+			if o.Verb != VerbRolloutRestart || o.Resource != "sts" {
+				return fmt.Errorf("expected rollout-restart of sts, got %v %q", o.Verb, o.Resource)
+			}
+			return nil
+		}},
+		{"--node-pressure", []string{"get", "nodes", "--node-not-ready", "--node-disk-pressure"}, func(o CLIOptions) error {
+			if !o.NodeNotReady || len(o.NodePressure) != 1 || o.NodePressure[0] != "DiskPressure" {
+				return fmt.Errorf("expected NodeNotReady and NodePressure=[DiskPressure], got %v %v", o.NodeNotReady, o.NodePressure)
+			}
+			return nil
+		}},
+		{"--pod-ip", []string{"get", "pods", "-A", "--pod-ip", "10.244.0.0/16", "--host-ip", "10.0.1.*"}, func(o CLIOptions) error {
+			if len(o.PodIPs) != 1 || o.PodIPs[0] != "10.244.0.0/16" || len(o.HostIPs) != 1 || o.HostIPs[0] != "10.0.1.*" {
+				return fmt.Errorf("unexpected PodIPs=%v HostIPs=%v", o.PodIPs, o.HostIPs)
+			}
+			return nil
+		}},
+		{"--qos", []string{"get", "pods", "-A", "--qos", "besteffort"}, func(o CLIOptions) error {
+			if !reflect.DeepEqual(o.QOSClasses, []string{"BestEffort"}) {
+				return fmt.Errorf("QOSClasses=%v", o.QOSClasses)
+			}
+			return nil
+		}},
+		{"--image", []string{"get", "pods", "-A", "--image", "docker.io/*", "--image-regex", "nginx:1\\.1[0-9]"}, func(o CLIOptions) error {
+			if !reflect.DeepEqual(o.Image, []string{"docker.io/*"}) || !reflect.DeepEqual(o.ImageRegex, []string{`nginx:1\.1[0-9]`}) {
+				return fmt.Errorf("Image=%v ImageRegex=%v", o.Image, o.ImageRegex)
+			}
+			return nil
+		}},
+		{"--label negation", []string{"get", "pods", "-A", "--label", "!debug", "--label", "app!=web"}, func(o CLIOptions) error {
+			if len(o.LabelFilters) != 2 || o.LabelFilters[0].Mode != LabelAbsent || o.LabelFilters[0].Key != "debug" ||
+				o.LabelFilters[1].Mode != LabelNotGlob || o.LabelFilters[1].Key != "app" || o.LabelFilters[1].Pattern != "web" {
+				return fmt.Errorf("unexpected LabelFilters %+v", o.LabelFilters)
+			}
+			return nil
+		}},
+		{"--has-label", []string{"get", "pods", "-A", "--has-label", "app", "--no-label", "istio.io/rev", "--has-annotation", "a", "--no-annotation", "b"}, func(o CLIOptions) error {
+			if !reflect.DeepEqual(o.HasLabel, []string{"app"}) || !reflect.DeepEqual(o.NoLabel, []string{"istio.io/rev"}) ||
+				!reflect.DeepEqual(o.HasAnnotation, []string{"a"}) || !reflect.DeepEqual(o.NoAnnotation, []string{"b"}) {
+				return fmt.Errorf("unexpected presence filters %v %v %v %v", o.HasLabel, o.NoLabel, o.HasAnnotation, o.NoAnnotation)
+			}
+			return nil
+		}},
+		{"--label-in", []string{"get", "pods", "-A", "--label-in", "env=prod,staging"}, func(o CLIOptions) error {
+			if len(o.LabelFilters) != 1 || o.LabelFilters[0].Mode != LabelIn || !reflect.DeepEqual(o.LabelFilters[0].Values, []string{"prod", "staging"}) {
+				return fmt.Errorf("unexpected LabelFilters %+v", o.LabelFilters)
+			}
+			return nil
+		}},
+		{"--label-gt", []string{"get", "pods", "-A", "--label-gt", "revision=5"}, func(o CLIOptions) error {
+			if len(o.LabelFilters) != 1 || o.LabelFilters[0].Mode != LabelNumeric || o.LabelFilters[0].Pattern != ">5" {
+				return fmt.Errorf("unexpected LabelFilters %+v", o.LabelFilters)
+			}
+			return nil
+		}},
+		{"--group-by-owner", []string{"get", "pods", "-A", "--group-by-owner"}, func(o CLIOptions) error {
+			if !o.GroupByOwner {
+				return fmt.Errorf("expected GroupByOwner=true")
+			}
+			return nil
+		}},
+		{"--selectivity", []string{"get", "pods", "web-*", "--selectivity"}, func(o CLIOptions) error {
+			if !o.Selectivity {
+				return fmt.Errorf("expected Selectivity=true")
+			}
+			return nil
+		}},
+		{"--grace-period-longer-than", []string{"get", "pods", "-A", "--grace-period-longer-than", "120"}, func(o CLIOptions) error {
+			if o.GracePeriodLongerThan != 120 {
+				return fmt.Errorf("GracePeriodLongerThan=%d", o.GracePeriodLongerThan)
+			}
+			return nil
+		}},
+		{"--sort-reverse", []string{"get", "pods", "-A", "--sort-by=age", "--sort-reverse"}, func(o CLIOptions) error {
+			if o.SortBy != "age" || !o.SortReverse {
+				return fmt.Errorf("SortBy=%q SortReverse=%v", o.SortBy, o.SortReverse)
+			}
+			return nil
+		}},
+		{"--replicas-unready", []string{"get", "sts", "-A", "--replicas-unready"}, func(o CLIOptions) error {
+			if !o.ReplicasUnready {
+				return fmt.Errorf("expected ReplicasUnready=true")
+			}
+			return nil
+		}},
+		{"--limit", []string{"delete", "pods", "evicted-*", "--sort-by", "age", "--limit", "10"}, func(o CLIOptions) error {
+			if o.Limit != 10 || o.SortBy != "age" {
+				return fmt.Errorf("expected Limit=10 SortBy=age, got %d %q", o.Limit, o.SortBy)
+			}
+			return nil
+		}},
+		{"--no-readiness-probe", []string{"get", "pods", "*", "-A", "--no-readiness-probe", "--no-liveness-probe"}, func(o CLIOptions) error {
+			if !o.NoReadinessProbe || !o.NoLivenessProbe {
+				return fmt.Errorf("expected NoReadinessProbe and NoLivenessProbe")
+			}
+			return nil
+		}},
+		{"--max-parallel", []string{"delete", "pods", "tmp-*", "-A", "--max-parallel", "4"}, func(o CLIOptions) error {
+			if o.MaxParallel != 4 {
+				return fmt.Errorf("expected MaxParallel=4, got %d", o.MaxParallel)
+			}
+			return nil
+		}},
+		{"--continue-on-error", []string{"delete", "pods", "tmp-*", "--continue-on-error"}, func(o CLIOptions) error {
+			if !o.ContinueOnError {
+				return fmt.Errorf("expected ContinueOnError=true")
+			}
+			return nil
+		}},
+		{"--stale-replicaset", []string{"get", "rs", "web-*", "--stale-replicaset"}, func(o CLIOptions) error {
+			if !o.StaleReplicaSet {
+				return fmt.Errorf("expected StaleReplicaSet=true")
+			}
+			return nil
+		}},
+		{"wait", []string{"wait", "pods", "job-*", "--", "--for=condition=Ready"}, func(o CLIOptions) error {
+			if o.Verb != VerbWait || !reflect.DeepEqual(o.ExtraFinal, []string{"--for=condition=Ready"}) {
+				return fmt.Errorf("expected wait with --for in ExtraFinal, got %v %v", o.Verb, o.ExtraFinal)
+			}
+			return nil
+		}},
+		{"--no-suggest", []string{"get", "pods", "ngnx", "--no-suggest"}, func(o CLIOptions) error {
+			if !o.NoSuggest || !reflect.DeepEqual(o.Include, []string{"ngnx"}) {
+				return fmt.Errorf("expected NoSuggest with pattern ngnx, got %v %v", o.NoSuggest, o.Include)
+			}
+			return nil
+		}},
+		{"patch", []string{"patch", "deploy", "web-*", "--", "--type=merge", "-p", "{}"}, func(o CLIOptions) error {
+			if o.Verb != VerbPatch || !reflect.DeepEqual(o.ExtraFinal, []string{"--type=merge", "-p", "{}"}) {
+				return fmt.Errorf("expected patch with the tail as ExtraFinal, got %v %v", o.Verb, o.ExtraFinal)
+			}
+			return nil
+		}},
+		{"annotate", []string{"annotate", "deploy", "api-*", "owner=team-a", "--overwrite"}, func(o CLIOptions) error {
+			if o.Verb != VerbAnnotate || !reflect.DeepEqual(o.Mutations, []string{"owner=team-a"}) || !containsFlag(o.FinalFlags, "--overwrite") {
+				return fmt.Errorf("expected annotate owner=team-a --overwrite, got %v %v %v", o.Verb, o.Mutations, o.FinalFlags)
+			}
+			return nil
+		}},
+		{"--kubeconfigs", []string{"get", "pods", "*", "--kubeconfigs", "a.yaml,b.yaml"}, func(o CLIOptions) error {
+			if !reflect.DeepEqual(o.Kubeconfigs, []string{"a.yaml", "b.yaml"}) {
+				return fmt.Errorf("expected Kubeconfigs=[a.yaml b.yaml], got %v", o.Kubeconfigs)
+			}
+			return nil
+		}},
+		{"--sort-by", []string{"get", "pods", "*", "-A", "--client-table", "--sort-by", "age"}, func(o CLIOptions) error {
+			if o.SortBy != "age" {
+				return fmt.Errorf("expected SortBy=age, got %q", o.SortBy)
+			}
+			return nil
+		}},
+		{"--replicas", []string{"scale", "deploy", "batch-*", "--replicas", "0"}, func(o CLIOptions) error {
+			if o.Verb != VerbScale || o.Replicas != 0 {
+				return fmt.Errorf("expected scale with Replicas=0, got %v %d", o.Verb, o.Replicas)
+			}
+			return nil
+		}},
+		{"--events", []string{"get", "pods", "web-*", "-A", "--events"}, func(o CLIOptions) error {
+			if !o.Events {
+				return fmt.Errorf("expected Events=true")
+			}
+			return nil
+		}},
+		{"--require-namespace", []string{"get", "pods", "*", "-A", "--require-namespace"}, func(o CLIOptions) error {
+			if !o.RequireNamespace {
+				return fmt.Errorf("expected RequireNamespace=true")
+			}
+			return nil
+		}},
+		{"--ordinal-range", []string{"get", "pods", "db-*", "--ordinal-range", "1-3"}, func(o CLIOptions) error {
+			if !o.OrdinalRangeSet || o.OrdinalMin != 1 || o.OrdinalMax != 3 {
+				return fmt.Errorf("expected ordinal range 1-3, got %v %d-%d", o.OrdinalRangeSet, o.OrdinalMin, o.OrdinalMax)
+			}
+			return nil
+		}},
+		{"--ns-exclude-prefix", []string{"get", "pods", "*", "--ns-exclude-prefix", "kube-", "--ns-exclude", "default"}, func(o CLIOptions) error {
+			if !reflect.DeepEqual(o.NsExcludePrefix, []string{"kube-"}) || !reflect.DeepEqual(o.NsExclude, []string{"default"}) || !o.AllNamespaces {
+				return fmt.Errorf("unexpected excludes %v %v (AllNamespaces=%v)", o.NsExcludePrefix, o.NsExclude, o.AllNamespaces)
+			}
+			return nil
+		}},
+		{"--client-table", []string{"get", "pods", "*", "-A", "--client-table"}, func(o CLIOptions) error {
+			if !o.ClientTable {
+				return fmt.Errorf("expected ClientTable=true")
+			}
+			return nil
+		}},
+		{"--has-affinity", []string{"get", "pods", "*", "--has-affinity"}, func(o CLIOptions) error {
+			if !o.HasAffinity || o.NoAffinity {
+				return fmt.Errorf("expected HasAffinity=true, got %v %v", o.HasAffinity, o.NoAffinity)
+			}
+			return nil
+		}},
+		{"--invert", []string{"get", "pods", "api-*", "-v"}, func(o CLIOptions) error {
+			if !o.Invert || !reflect.DeepEqual(o.Include, []string{"api-*"}) {
+				return fmt.Errorf("expected Invert=true Include=[api-*], got %v %v", o.Invert, o.Include)
+			}
+			return nil
+		}},
+		{"--sample", []string{"describe", "pods", "web-*", "--sample", "2", "--seed", "1"}, func(o CLIOptions) error {
+			if o.Sample != 2 || !o.SeedSet || o.Seed != 1 {
+				return fmt.Errorf("expected Sample=2 Seed=1, got %d %v %d", o.Sample, o.SeedSet, o.Seed)
+			}
+			return nil
+		}},
+		{"--error-on-empty", []string{"get", "pods", "*", "--error-on-empty"}, func(o CLIOptions) error {
+			if !o.ErrorOnEmpty {
+				return fmt.Errorf("expected ErrorOnEmpty=true")
+			}
+			return nil
+		}},
+		{"--condition", []string{"get", "deploy", "*", "--condition", "Available=False"}, func(o CLIOptions) error {
+			if len(o.Conditions) != 1 || o.Conditions[0].Key != "Available" || o.Conditions[0].Pattern != "False" {
+				return fmt.Errorf("expected Conditions=[Available=False], got %+v", o.Conditions)
+			}
+			return nil
+		}},
+		{"--names-only", []string{"get", "pods", "*", "-q"}, func(o CLIOptions) error {
+			if !o.NamesOnly || o.Print0 {
+				return fmt.Errorf("expected NamesOnly=true Print0=false, got %v %v", o.NamesOnly, o.Print0)
+			}
+			return nil
+		}},
+		{"--print0", []string{"get", "pods", "*", "--print0"}, func(o CLIOptions) error {
+			if !o.NamesOnly || !o.Print0 {
+				return fmt.Errorf("expected --print0 to imply --names-only")
+			}
+			return nil
+		}},
+		{"--ready-containers", []string{"get", "pods", "*", "--ready-containers", "<2"}, func(o CLIOptions) error {
+			if o.ReadyContainersExpr != "<2" {
+				return fmt.Errorf("expected ReadyContainersExpr=<2, got %q", o.ReadyContainersExpr)
+			}
+			return nil
+		}},
+		{"--owner-kind", []string{"delete", "pods", "*", "--owner-kind", "Job", "--owner-name", "backup-*"}, func(o CLIOptions) error {
+			if !reflect.DeepEqual(o.OwnerKinds, []string{"Job"}) || !reflect.DeepEqual(o.OwnerNames, []string{"backup-*"}) {
+				return fmt.Errorf("expected OwnerKinds=[Job] OwnerNames=[backup-*], got %v %v", o.OwnerKinds, o.OwnerNames)
+			}
+			return nil
+		}},
+		{"--uses-configmap", []string{"get", "pods", "*", "--uses-configmap", "app-*", "--uses-secret", "tls"}, func(o CLIOptions) error {
+			if !reflect.DeepEqual(o.UsesConfigMap, []string{"app-*"}) || !reflect.DeepEqual(o.UsesSecret, []string{"tls"}) {
+				return fmt.Errorf("expected UsesConfigMap=[app-*] UsesSecret=[tls], got %v %v", o.UsesConfigMap, o.UsesSecret)
+			}
+			return nil
+		}},
+		{"--uses-pvc", []string{"get", "pods", "*", "--uses-pvc", "data-*"}, func(o CLIOptions) error {
+			if !reflect.DeepEqual(o.UsesPVC, []string{"data-*"}) {
+				return fmt.Errorf("expected UsesPVC=[data-*], got %v", o.UsesPVC)
+			}
+			return nil
+		}},
+		{"--show-owner", []string{"get", "pods", "*", "-A", "--show-owner"}, func(o CLIOptions) error {
+			if !o.ShowOwner {
+				return fmt.Errorf("expected ShowOwner=true")
+			}
+			return nil
+		}},
+		{"--preview-limit", []string{"delete", "pods", "*", "--preview-limit", "10"}, func(o CLIOptions) error {
+			if o.PreviewLimit != 10 {
+				return fmt.Errorf("expected PreviewLimit=10, got %d", o.PreviewLimit)
+			}
+			return nil
+		}},
+		{"--as-of", []string{"get", "pods", "*", "--older-than", "1h", "--as-of", "2025-01-02T15:04:05Z"}, func(o CLIOptions) error {
+			if want := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC); !o.AsOf.Equal(want) {
+				return fmt.Errorf("expected AsOf=%v, got %v", want, o.AsOf)
+			}
+			return nil
+		}},
 		{"--older-than", []string{"get", "pods", "*", "--older-than", "1h", "-A"}, func(o CLIOptions) error {
 			if o.OlderThan != time.Hour {
 				return fmt.Errorf("expected OlderThan=1h, got %v", o.OlderThan)
@@ -1863,6 +2199,26 @@ func TestAllCLIFlags(t *testing.T) {
 			return nil
 		}},
 
+		// FINALIZER FLAGS
+		{"--has-finalizers", []string{"get", "pods", "*", "--has-finalizers", "-A"}, func(o CLIOptions) error {
+			if !o.HasFinalizers {
+				return fmt.Errorf("expected HasFinalizers=true")
+			}
+			return nil
+		}},
+		{"--finalizer (repeatable)", []string{"get", "pvc", "*", "--finalizer", "kubernetes.io/pvc-protection", "--finalizer", "foregroundDeletion"}, func(o CLIOptions) error {
+			if !reflect.DeepEqual(o.Finalizers, []string{"kubernetes.io/pvc-protection", "foregroundDeletion"}) {
+				return fmt.Errorf("expected Finalizers to be set, got %v", o.Finalizers)
+			}
+			return nil
+		}},
+		{"--churning", []string{"get", "pods", "*", "--churning", "--churning-age", "48h", "--churning-restarts", "10", "-A"}, func(o CLIOptions) error {
+			if !o.Churning || o.ChurningAge != 48*time.Hour || o.ChurningRestarts != 10 {
+				return fmt.Errorf("expected churning 48h/10, got %v %v %v", o.Churning, o.ChurningAge, o.ChurningRestarts)
+			}
+			return nil
+		}},
+
 		// NODE FLAGS
 		{"--node", []string{"get", "pods", "*", "--node", "node1", "-A"}, func(o CLIOptions) error {
 			if len(o.NodeExact) == 0 || o.NodeExact[0] != "node1" {
@@ -1882,6 +2238,30 @@ func TestAllCLIFlags(t *testing.T) {
 			}
 			return nil
 		}},
+		{"--node-selector", []string{"get", "pods", "*", "--node-selector", "disktype=ssd", "-A"}, func(o CLIOptions) error {
+			if len(o.NodeSelectorFilters) != 1 || o.NodeSelectorFilters[0].Key != "disktype" || o.NodeSelectorFilters[0].Pattern != "ssd" {
+				return fmt.Errorf("expected NodeSelectorFilters=[disktype=ssd], got %v", o.NodeSelectorFilters)
+			}
+			return nil
+		}},
+		{"--no-node-selector", []string{"get", "pods", "*", "--no-node-selector", "-A"}, func(o CLIOptions) error {
+			if !o.NoNodeSelector {
+				return fmt.Errorf("expected NoNodeSelector=true")
+			}
+			return nil
+		}},
+		{"--tolerates", []string{"get", "pods", "*", "--tolerates", "node-role.kubernetes.io/control-plane", "-A"}, func(o CLIOptions) error {
+			if len(o.Tolerates) != 1 || o.Tolerates[0] != "node-role.kubernetes.io/control-plane" {
+				return fmt.Errorf("expected Tolerates to be set, got %v", o.Tolerates)
+			}
+			return nil
+		}},
+		{"--restart-policy", []string{"get", "pods", "*", "--restart-policy", "onfailure"}, func(o CLIOptions) error {
+			if o.RestartPolicy != "OnFailure" {
+				return fmt.Errorf("expected RestartPolicy=OnFailure, got %q", o.RestartPolicy)
+			}
+			return nil
+		}},
 
 		// SAFETY FLAGS (delete)
 		{"--dry-run", []string{"delete", "pods", "test*", "--dry-run"}, func(o CLIOptions) error {
@@ -1902,6 +2282,18 @@ func TestAllCLIFlags(t *testing.T) {
 			}
 			return nil
 		}},
+		{"--emit-revert", []string{"delete", "pods", "test*", "--emit-revert", "revert.yaml"}, func(o CLIOptions) error {
+			if o.EmitRevert != "revert.yaml" {
+				return fmt.Errorf("expected EmitRevert=revert.yaml, got %v", o.EmitRevert)
+			}
+			return nil
+		}},
+		{"--remove-finalizers", []string{"delete", "pods", "stuck-*", "--remove-finalizers"}, func(o CLIOptions) error {
+			if !o.RemoveFinalizers {
+				return fmt.Errorf("expected RemoveFinalizers=true")
+			}
+			return nil
+		}},
 		{"--yes", []string{"delete", "pods", "test*", "--yes"}, func(o CLIOptions) error {
 			if !o.Yes {
 				return fmt.Errorf("expected Yes=true")
@@ -1920,6 +2312,12 @@ func TestAllCLIFlags(t *testing.T) {
 			}
 			return nil
 		}},
+		{"--color=always", []string{"delete", "pods", "test*", "--color=always"}, func(o CLIOptions) error {
+			if o.NoColor {
+				return fmt.Errorf("expected NoColor=false")
+			}
+			return nil
+		}},
 		{"--no-color", []string{"delete", "pods", "test*", "--no-color"}, func(o CLIOptions) error {
 			if !o.NoColor {
 				return fmt.Errorf("expected NoColor=true")
@@ -1934,6 +2332,12 @@ func TestAllCLIFlags(t *testing.T) {
 			}
 			return nil
 		}},
+		{"--retry-conflict", []string{"delete", "pods", "stuck-*", "--remove-finalizers", "--retry-conflict", "3"}, func(o CLIOptions) error {
+			if o.RetryConflict != 3 {
+				return fmt.Errorf("expected RetryConflict=3, got %v", o.RetryConflict)
+			}
+			return nil
+		}},
 		{"--debug", []string{"get", "pods", "*", "--debug"}, func(o CLIOptions) error {
 			if !o.Debug {
 				return fmt.Errorf("expected Debug=true")
@@ -1941,6 +2345,82 @@ func TestAllCLIFlags(t *testing.T) {
 			return nil
 		}},
 
+		{"--unscheduled", []string{"get", "pods", "*", "--unscheduled", "-A"}, func(o CLIOptions) error {
+			if !o.Unscheduled {
+				return fmt.Errorf("expected Unscheduled=true")
+			}
+			return nil
+		}},
+		{"--scheduling-gated", []string{"get", "pods", "*", "--scheduling-gated", "-A"}, func(o CLIOptions) error {
+			if !o.SchedulingGated {
+				return fmt.Errorf("expected SchedulingGated=true")
+			}
+			return nil
+		}},
+		{"--metrics", []string{"get", "pods", "*", "-A", "--metrics"}, func(o CLIOptions) error {
+			if !o.Metrics {
+				return fmt.Errorf("expected Metrics=true")
+			}
+			return nil
+		}},
+		{"--pager --no-pager", []string{"describe", "pods", "*", "--pager", "--no-pager"}, func(o CLIOptions) error {
+			if !o.Pager || !o.NoPager {
+				return fmt.Errorf("expected Pager and NoPager, got %v %v", o.Pager, o.NoPager)
+			}
+			if usePager(o) {
+				return fmt.Errorf("--no-pager must disable the pager")
+			}
+			return nil
+		}},
+		{"--count-by", []string{"get", "pods", "*", "-A", "--count-by", "label:app"}, func(o CLIOptions) error {
+			if o.CountBy != "label:app" {
+				return fmt.Errorf("expected CountBy=label:app, got %q", o.CountBy)
+			}
+			return nil
+		}},
+		{"--names-status", []string{"get", "pods", "*", "--names-status", "--output-separator", ","}, func(o CLIOptions) error {
+			if !o.NamesStatus || o.OutputSeparator != "," {
+				return fmt.Errorf("expected NamesStatus with separator \",\", got %v %q", o.NamesStatus, o.OutputSeparator)
+			}
+			return nil
+		}},
+		{"--format json", []string{"get", "pods", "*", "--format=json"}, func(o CLIOptions) error {
+			if !o.JSON {
+				return fmt.Errorf("expected JSON=true")
+			}
+			return nil
+		}},
+		{"--json --bare", []string{"get", "pods", "*", "--json", "--bare"}, func(o CLIOptions) error {
+			if !o.JSON || !o.Bare {
+				return fmt.Errorf("expected JSON=true Bare=true")
+			}
+			return nil
+		}},
+		{"--compact-describe", []string{"describe", "pods", "*", "--compact-describe"}, func(o CLIOptions) error {
+			if !o.CompactDescribe {
+				return fmt.Errorf("expected CompactDescribe=true")
+			}
+			return nil
+		}},
+		{"--poll-until-empty", []string{"get", "pods", "canary-*", "--poll-until-empty", "2m"}, func(o CLIOptions) error {
+			if o.PollTimeout != 2*time.Minute || o.PollUntilCount != 0 {
+				return fmt.Errorf("expected PollTimeout=2m PollUntilCount=0, got %v %v", o.PollTimeout, o.PollUntilCount)
+			}
+			return nil
+		}},
+		{"--poll-until-count", []string{"get", "pods", "web-*", "--poll-until-count", "3"}, func(o CLIOptions) error {
+			if o.PollUntilCount != 3 || o.PollTimeout != defaultPollTimeout {
+				return fmt.Errorf("expected PollUntilCount=3 with default timeout, got %v %v", o.PollUntilCount, o.PollTimeout)
+			}
+			return nil
+		}},
+		{"--poll-timeout", []string{"get", "pods", "web-*", "--poll-until-count", "3", "--poll-timeout", "30s"}, func(o CLIOptions) error {
+			if o.PollTimeout != 30*time.Second {
+				return fmt.Errorf("expected PollTimeout=30s, got %v", o.PollTimeout)
+			}
+			return nil
+		}},
+
 		// PATTERN POSITION TESTS (the fix)
 		{"pattern before -n", []string{"get", "pods", "xyz*", "-n", "default"}, func(o CLIOptions) error {
 			if len(o.Include) == 0 || o.Include[0] != "xyz*" {
@@ -1986,3 +2466,3599 @@ func TestAllCLIFlags(t *testing.T) {
 		})
 	}
 }
+
+// finalArgs joins the args of all non-discovery `kubectl <verb> <resource> ...` calls,
+// padded with spaces so tests can check for " name " membership.
+func finalArgs(fr *fakeRunner, verb, resource string) string {
+	var parts []string
+	for _, c := range fr.calls {
+		if len(c) >= 3 && c[0] == verb && c[1] == resource && c[2] != "-o" {
+			parts = append(parts, c[2:]...)
+		}
+	}
+	return " " + strings.Join(parts, " ") + " "
+}
+
+// listRunner answers every `kubectl get <resource> -o json ...` list with the same
+// fixture, so filter tests don't have to spell out the discovery call.
+type listRunner struct {
+	fakeRunner
+	list string
+}
+
+func (r *listRunner) CaptureKubectl(args []string) ([]byte, []byte, error) {
+	if len(args) >= 4 && args[0] == "get" && args[2] == "-o" && args[3] == "json" {
+		r.calls = append(r.calls, append([]string{}, args...))
+		return []byte(r.list), nil, nil
+	}
+	return r.fakeRunner.CaptureKubectl(args)
+}
+
+// matchedRefs parses argv and runs discovery against listJSON.
+func matchedRefs(t *testing.T, listJSON string, argv ...string) []matchedRef {
+	t.Helper()
+	opts, err := parseArgs(argv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &listRunner{fakeRunner: fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}, list: listJSON}
+	matched, err := discoverMatched(r, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return matched
+}
+
+// matchedNames joins the names matched by argv, in match order.
+func matchedNames(t *testing.T, listJSON string, argv ...string) string {
+	t.Helper()
+	var names []string
+	for _, m := range matchedRefs(t, listJSON, argv...) {
+		names = append(names, m.name)
+	}
+	return strings.Join(names, ",")
+}
+
+// matchedKeys is matchedNames with namespace/name keys, for -A tests.
+func matchedKeys(t *testing.T, listJSON string, argv ...string) string {
+	t.Helper()
+	var keys []string
+	for _, m := range matchedRefs(t, listJSON, argv...) {
+		keys = append(keys, m.ns+"/"+m.name)
+	}
+	return strings.Join(keys, ",")
+}
+
+// runStderr parses argv, runs it against r and returns what it wrote to stderr.
+func runStderr(t *testing.T, r Runner, argv ...string) string {
+	t.Helper()
+	opts, err := parseArgs(argv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	origStderr := os.Stderr
+	os.Stderr = pw
+	runErr := runCommand(r, opts)
+	os.Stderr = origStderr
+	pw.Close()
+	out, _ := io.ReadAll(pr)
+	if runErr != nil {
+		t.Fatal(runErr)
+	}
+	return string(out)
+}
+
+func TestUnscheduled_PendingWithoutNode(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	now := time.Now().UTC().Format(time.RFC3339)
+	json := fmt.Sprintf("{\"items\":["+
+		"{\"metadata\":{\"name\":\"unsched\",\"namespace\":\"ns\",\"creationTimestamp\":\"%s\"},\"spec\":{},\"status\":{\"phase\":\"Pending\"}},"+
+		"{\"metadata\":{\"name\":\"pulling\",\"namespace\":\"ns\",\"creationTimestamp\":\"%s\"},\"spec\":{\"nodeName\":\"node-1\"},\"status\":{\"phase\":\"Pending\"}}]}", now, now)
+	fr.outputs["get pods -o json"] = json
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"*"}, Mode: MatchGlob, Unscheduled: true}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	joined := finalArgs(fr, "get", "pods")
+	if !strings.Contains(joined, " unsched ") || strings.Contains(joined, " pulling ") {
+		t.Fatalf("expected only unscheduled pending pod; calls=%v", fr.calls)
+	}
+}
+
+func TestEmitRevert_WritesDeletedObjects(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = "{\"items\":[" +
+		"{\"apiVersion\":\"v1\",\"kind\":\"Pod\",\"metadata\":{\"name\":\"te1\",\"namespace\":\"ns\",\"resourceVersion\":\"42\",\"uid\":\"u1\",\"labels\":{\"app\":\"te\"}},\"spec\":{\"containers\":[{\"name\":\"c\",\"image\":\"nginx:1\"}]},\"status\":{\"phase\":\"Running\"}}," +
+		"{\"apiVersion\":\"v1\",\"kind\":\"Pod\",\"metadata\":{\"name\":\"te2\",\"namespace\":\"ns\"},\"spec\":{\"containers\":[]}}," +
+		"{\"apiVersion\":\"v1\",\"kind\":\"Pod\",\"metadata\":{\"name\":\"keep\",\"namespace\":\"ns\"}}]}"
+	path := t.TempDir() + "/revert.yaml"
+	opts := CLIOptions{Verb: VerbDelete, Resource: "pods", Include: []string{"te*"}, Mode: MatchGlob, Yes: true, EmitRevert: path}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, want := range []string{"kind: \"List\"", "name: \"te1\"", "name: \"te2\"", "image: \"nginx:1\"", "app: \"te\""} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in revert manifest:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"keep", "resourceVersion", "uid", "status"} {
+		if strings.Contains(out, unwanted) {
+			t.Fatalf("did not expect %q in revert manifest:\n%s", unwanted, out)
+		}
+	}
+	if joined := finalArgs(fr, "delete", "pods"); !strings.Contains(joined, " te1 ") || !strings.Contains(joined, " te2 ") {
+		t.Fatalf("expected delete of te1 and te2; calls=%v", fr.calls)
+	}
+	// The manifest comes from discovery, not from a second list call
+	lists := 0
+	for _, c := range fr.calls {
+		if strings.Join(c, " ") == "get pods -o json" {
+			lists++
+		}
+	}
+	if lists != 1 {
+		t.Fatalf("expected one list call, got %d: %v", lists, fr.calls)
+	}
+}
+
+func TestEmitRevert_NoSavedMessageOnPartialFailure(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"te1\",\"namespace\":\"ns\"}}," +
+		"{\"metadata\":{\"name\":\"te2\",\"namespace\":\"ns\"}}]}"
+	fr.errs["delete pods te2"] = exitCodeErr{code: 1}
+	path := t.TempDir() + "/revert.yaml"
+	opts := CLIOptions{Verb: VerbDelete, Resource: "pods", Include: []string{"te*"}, Mode: MatchGlob, Yes: true, EmitRevert: path, ContinueOnError: true, BatchSize: 1}
+
+	r, w, _ := os.Pipe()
+	origStdout, origStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = w, w
+	err := runCommand(fr, opts)
+	w.Close()
+	os.Stdout, os.Stderr = origStdout, origStderr
+	out, _ := io.ReadAll(r)
+
+	if err == nil {
+		t.Fatalf("expected the failed delete to be reported; calls=%v", fr.calls)
+	}
+	if strings.Contains(string(out), "Saved ") || !strings.Contains(string(out), "Not every delete succeeded") {
+		t.Fatalf("expected a partial-failure note instead of Saved, got %q", out)
+	}
+}
+
+func TestSchedulingGated_OnlyGatedPods(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	now := time.Now().UTC().Format(time.RFC3339)
+	json := fmt.Sprintf("{\"items\":["+
+		"{\"metadata\":{\"name\":\"gated\",\"namespace\":\"ns\",\"creationTimestamp\":\"%s\"},\"spec\":{\"schedulingGates\":[{\"name\":\"example.com/quota\"}]},\"status\":{\"phase\":\"Pending\"}},"+
+		"{\"metadata\":{\"name\":\"ungated\",\"namespace\":\"ns\",\"creationTimestamp\":\"%s\"},\"spec\":{},\"status\":{\"phase\":\"Pending\"}}]}", now, now)
+	fr.outputs["get pods -o json"] = json
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"*"}, Mode: MatchGlob, SchedulingGated: true}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	joined := finalArgs(fr, "get", "pods")
+	if !strings.Contains(joined, " gated ") || strings.Contains(joined, " ungated ") {
+		t.Fatalf("expected only gated pod; calls=%v", fr.calls)
+	}
+}
+
+// sequenceRunner returns successive outputs for a key on each capture, repeating the last one.
+type sequenceRunner struct {
+	fakeRunner
+	seq map[string][]string
+}
+
+func (s *sequenceRunner) CaptureKubectl(args []string) ([]byte, []byte, error) {
+	if outs, ok := s.seq[s.key(args)]; ok && len(outs) > 0 {
+		s.calls = append(s.calls, append([]string{}, args...))
+		out := outs[0]
+		if len(outs) > 1 {
+			s.seq[s.key(args)] = outs[1:]
+		}
+		return []byte(out), nil, nil
+	}
+	return s.fakeRunner.CaptureKubectl(args)
+}
+
+func TestPollUntilEmpty_SucceedsWhenMatchesDrain(t *testing.T) {
+	origInterval, origSleep := pollInterval, pollSleep
+	defer func() { pollInterval, pollSleep = origInterval, origSleep }()
+	pollInterval = time.Millisecond
+	sleeps := 0
+	pollSleep = func(time.Duration) { sleeps++ }
+
+	sr := &sequenceRunner{
+		fakeRunner: fakeRunner{outputs: map[string]string{}, errs: map[string]error{}},
+		seq: map[string][]string{"get pods -o json": {
+			discoveryJSON("canary-1", "canary-2", "web-1"),
+			discoveryJSON("canary-2", "web-1"),
+			discoveryJSON("web-1"),
+		}},
+	}
+	opts, err := parseArgs([]string{"get", "pods", "canary-*", "--poll-until-empty", "1m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(sr, opts); err != nil {
+		t.Fatalf("expected success once canaries are gone, got %v", err)
+	}
+	if sleeps != 2 {
+		t.Fatalf("expected 2 waits before the set drained, got %d", sleeps)
+	}
+	if joined := finalArgs(&sr.fakeRunner, "get", "pods"); strings.TrimSpace(joined) != "" {
+		t.Fatalf("polling should not run a final kubectl get; calls=%v", sr.calls)
+	}
+}
+
+func TestPollUntilCount_TimesOut(t *testing.T) {
+	origInterval, origSleep, origNow := pollInterval, pollSleep, now
+	defer func() { pollInterval, pollSleep, now = origInterval, origSleep, origNow }()
+	clock := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return clock }
+	var slept []time.Duration
+	pollSleep = func(d time.Duration) {
+		slept = append(slept, d)
+		clock = clock.Add(d)
+	}
+	pollInterval = 2 * time.Second
+
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = discoveryJSON("web-1")
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"web-*"}, Mode: MatchGlob, PollTimeout: 5 * time.Second, PollUntilCount: 3}
+	err := runCommand(fr, opts)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	// Full intervals, then only what is left of the timeout
+	want := []time.Duration{2 * time.Second, 2 * time.Second, time.Second}
+	if !reflect.DeepEqual(slept, want) {
+		t.Fatalf("expected sleeps %v, got %v", want, slept)
+	}
+}
+
+func TestParseArgs_PollUntilEmptyAndCountExclusive(t *testing.T) {
+	for _, argv := range [][]string{
+		{"get", "pods", "web-*", "--poll-until-count", "3", "--poll-until-empty", "5m"},
+		{"get", "pods", "web-*", "--poll-until-empty", "5m", "--poll-until-count", "3"},
+	} {
+		if _, err := parseArgs(argv); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+			t.Fatalf("%v: expected mutually exclusive error, got %v", argv, err)
+		}
+	}
+}
+
+func TestParseArgs_PollTimeoutNeedsPollUntilCount(t *testing.T) {
+	for _, argv := range [][]string{
+		{"get", "pods", "web-*", "--poll-timeout", "1m"},
+		{"get", "pods", "web-*", "--poll-until-empty", "30s", "--poll-timeout", "1m"},
+		{"get", "pods", "web-*", "--poll-timeout", "1m", "--poll-until-empty", "30s"},
+	} {
+		if _, err := parseArgs(argv); err == nil || !strings.Contains(err.Error(), "--poll-timeout") {
+			t.Fatalf("%v: expected --poll-timeout error, got %v", argv, err)
+		}
+	}
+	opts, err := parseArgs([]string{"get", "pods", "web-*", "--poll-timeout", "1m", "--poll-until-count", "3"})
+	if err != nil || opts.PollTimeout != time.Minute || opts.PollUntilCount != 3 {
+		t.Fatalf("expected --poll-timeout with --poll-until-count to parse, got %+v %v", opts.PollTimeout, err)
+	}
+}
+
+func TestFinalizerFilters(t *testing.T) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	json := fmt.Sprintf("{\"items\":["+
+		"{\"metadata\":{\"name\":\"stuck\",\"namespace\":\"ns\",\"creationTimestamp\":\"%s\",\"deletionTimestamp\":\"%s\",\"finalizers\":[\"example.com/cleanup\"]}},"+
+		"{\"metadata\":{\"name\":\"plain\",\"namespace\":\"ns\",\"creationTimestamp\":\"%s\"}}]}", now, now, now)
+	cases := []struct {
+		name string
+		opts CLIOptions
+		want bool
+	}{
+		{"has-finalizers", CLIOptions{HasFinalizers: true}, true},
+		{"finalizer match", CLIOptions{Finalizers: []string{"example.com/cleanup"}}, true},
+		{"finalizer mismatch", CLIOptions{Finalizers: []string{"other"}}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fr := &fakeRunner{outputs: map[string]string{"get pods -o json": json}, errs: map[string]error{}}
+			opts := tc.opts
+			opts.Verb, opts.Resource, opts.Include, opts.Mode = VerbGet, "pods", []string{"*"}, MatchGlob
+			if err := runCommand(fr, opts); err != nil {
+				t.Fatal(err)
+			}
+			joined := finalArgs(fr, "get", "pods")
+			if strings.Contains(joined, " plain ") {
+				t.Fatalf("pod without finalizers should never match; calls=%v", fr.calls)
+			}
+			if strings.Contains(joined, " stuck ") != tc.want {
+				t.Fatalf("stuck match=%v, want %v; calls=%v", !tc.want, tc.want, fr.calls)
+			}
+		})
+	}
+}
+
+func TestRemoveFinalizers_PatchesBeforeDelete(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -n ns"] = "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"stuck-1\",\"namespace\":\"ns\",\"finalizers\":[\"example.com/x\"]}}," +
+		"{\"metadata\":{\"name\":\"web\",\"namespace\":\"ns\"}}]}"
+	opts, err := parseArgs([]string{"delete", "pods", "stuck-*", "-n", "ns", "--remove-finalizers", "-y", "--no-color"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	patchIdx, deleteIdx := -1, -1
+	for i, c := range fr.calls {
+		switch {
+		case len(c) > 0 && c[0] == "patch":
+			want := []string{"patch", "pods", "stuck-1", "--type=merge", "-p", `{"metadata":{"finalizers":null}}`, "-n", "ns"}
+			if !reflect.DeepEqual(c, want) {
+				t.Fatalf("unexpected patch call: %v", c)
+			}
+			patchIdx = i
+		case len(c) > 2 && c[0] == "delete":
+			deleteIdx = i
+		}
+	}
+	if patchIdx == -1 || deleteIdx == -1 || patchIdx > deleteIdx {
+		t.Fatalf("expected patch before delete; calls=%v", fr.calls)
+	}
+}
+
+func TestParseArgs_RemoveFinalizersRequiresDelete(t *testing.T) {
+	if _, err := parseArgs([]string{"get", "pods", "*", "--remove-finalizers"}); err == nil {
+		t.Fatal("expected error for --remove-finalizers with get")
+	}
+}
+
+func TestPrintMetrics_AggregatesByNamespaceAndPhase(t *testing.T) {
+	matched := []matchedRef{
+		{ns: "prod", name: "a", phase: "Running"},
+		{ns: "prod", name: "b", phase: "Running"},
+		{ns: "prod", name: "c", phase: "Pending"},
+		{ns: "dev", name: "d", phase: "Running"},
+	}
+	var buf bytes.Buffer
+	printMetrics(&buf, CLIOptions{Resource: "pods"}, matched)
+	want := "# kube_wild_format_version 1\n" +
+		"# HELP kube_wild_matched Number of objects matched by kubectl-wild filters.\n" +
+		"# TYPE kube_wild_matched gauge\n" +
+		"kube_wild_matched{resource=\"pods\",namespace=\"dev\",phase=\"Running\"} 1\n" +
+		"kube_wild_matched{resource=\"pods\",namespace=\"prod\",phase=\"Pending\"} 1\n" +
+		"kube_wild_matched{resource=\"pods\",namespace=\"prod\",phase=\"Running\"} 2\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected metrics output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+	re := regexp.MustCompile(`^kube_wild_matched\{resource="[^"]*",namespace="[^"]*",phase="[^"]*"\} \d+$`)
+	for _, ln := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if !strings.HasPrefix(ln, "#") && !re.MatchString(ln) {
+			t.Fatalf("malformed metric line: %q", ln)
+		}
+	}
+	// The label doesn't depend on how the resource was typed
+	for _, typed := range []string{"po", "pod", "Pods"} {
+		var b bytes.Buffer
+		printMetrics(&b, CLIOptions{Resource: typed}, matched)
+		if b.String() != want {
+			t.Fatalf("%s: expected resource=\"pods\" series, got:\n%s", typed, b.String())
+		}
+	}
+}
+
+func TestMetrics_SuppressesKubectlOutput(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = discoveryJSON("a1", "a2")
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"a*"}, Mode: MatchGlob, Metrics: true}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	if joined := finalArgs(fr, "get", "pods"); strings.TrimSpace(joined) != "" {
+		t.Fatalf("--metrics should not run a final kubectl call; calls=%v", fr.calls)
+	}
+}
+
+func TestFullNameMatch_SingleNamespace(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -n ns1"] = "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"web-1\",\"namespace\":\"ns1\"}}," +
+		"{\"metadata\":{\"name\":\"api-1\",\"namespace\":\"ns1\"}}]}"
+	opts, err := parseArgs([]string{"get", "pods", "ns1/web-*", "-n", "ns1", "--full-name-match"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	joined := finalArgs(fr, "get", "pods")
+	if !strings.Contains(joined, " web-1 ") || strings.Contains(joined, " api-1 ") {
+		t.Fatalf("expected ns1/web-* to match web-1 only; calls=%v", fr.calls)
+	}
+	// Without the flag the composite pattern matches nothing in single-namespace mode
+	fr2 := &fakeRunner{outputs: fr.outputs, errs: map[string]error{}}
+	opts.FullNameMatch = false
+	if err := runCommand(fr2, opts); err != nil {
+		t.Fatal(err)
+	}
+	if joined := finalArgs(fr2, "get", "pods"); strings.Contains(joined, " web-1 ") {
+		t.Fatalf("expected no match without --full-name-match; calls=%v", fr2.calls)
+	}
+}
+
+func TestNodeSelectorFilters(t *testing.T) {
+	list := "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"ssd\",\"namespace\":\"ns\"},\"spec\":{\"nodeSelector\":{\"disktype\":\"ssd\"}}}," +
+		"{\"metadata\":{\"name\":\"hdd\",\"namespace\":\"ns\"},\"spec\":{\"nodeSelector\":{\"disktype\":\"hdd\"}}}," +
+		"{\"metadata\":{\"name\":\"any\",\"namespace\":\"ns\"},\"spec\":{}}]}"
+	if joined := matchedNames(t, list, "get", "pods", "*", "--node-selector", "disktype=ssd"); joined != "ssd" {
+		t.Fatalf("--node-selector disktype=ssd mismatch: %s", joined)
+	}
+	if joined := matchedNames(t, list, "get", "pods", "*", "--no-node-selector"); joined != "any" {
+		t.Fatalf("--no-node-selector mismatch: %s", joined)
+	}
+}
+
+func TestTolerates_ControlPlaneTaint(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"cp\",\"namespace\":\"ns\"},\"spec\":{\"tolerations\":[{\"key\":\"node-role.kubernetes.io/control-plane\",\"operator\":\"Exists\",\"effect\":\"NoSchedule\"}]}}," +
+		"{\"metadata\":{\"name\":\"everything\",\"namespace\":\"ns\"},\"spec\":{\"tolerations\":[{\"operator\":\"Exists\"}]}}," +
+		"{\"metadata\":{\"name\":\"plain\",\"namespace\":\"ns\"},\"spec\":{\"tolerations\":[{\"key\":\"node.kubernetes.io/not-ready\",\"operator\":\"Exists\"}]}}]}"
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"*"}, Mode: MatchGlob, Tolerates: []string{"node-role.kubernetes.io/control-plane"}}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	joined := finalArgs(fr, "get", "pods")
+	if !strings.Contains(joined, " cp ") || !strings.Contains(joined, " everything ") || strings.Contains(joined, " plain ") {
+		t.Fatalf("unexpected --tolerates result; calls=%v", fr.calls)
+	}
+}
+
+type exitCodeErr struct{ code int }
+
+func (e exitCodeErr) Error() string { return fmt.Sprintf("exit status %d", e.code) }
+func (e exitCodeErr) ExitCode() int { return e.code }
+
+func TestExitCode_PropagatesKubectlStatus(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = discoveryJSON("a1", "a2")
+	fr.errs["describe pods a1 a2"] = exitCodeErr{code: 5}
+	opts := CLIOptions{Verb: VerbDescribe, Resource: "pods", Include: []string{"a*"}, Mode: MatchGlob, BatchSize: 10}
+	err := runCommand(fr, opts)
+	if err == nil {
+		t.Fatal("expected error from describe batch")
+	}
+	if code := exitCodeFor(err); code != 5 {
+		t.Fatalf("expected exit code 5, got %d", code)
+	}
+	if code := exitCodeFor(fmt.Errorf("wrapped: %w", err)); code != 5 {
+		t.Fatalf("expected wrapped exit code 5, got %d", code)
+	}
+	if code := exitCodeFor(errors.New("plain")); code != 1 {
+		t.Fatalf("expected default exit code 1, got %d", code)
+	}
+}
+
+func TestChurning_OldPodWithRecentRestarts(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	old := time.Now().Add(-10 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	recent := time.Now().Add(-5 * time.Minute).UTC().Format(time.RFC3339)
+	longAgo := time.Now().Add(-9 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	fr.outputs["get pods -o json"] = fmt.Sprintf("{\"items\":["+
+		"{\"metadata\":{\"name\":\"stable\",\"namespace\":\"ns\",\"creationTimestamp\":\"%s\"},\"status\":{\"phase\":\"Running\",\"containerStatuses\":[{\"name\":\"app\",\"restartCount\":0}]}},"+
+		"{\"metadata\":{\"name\":\"churning\",\"namespace\":\"ns\",\"creationTimestamp\":\"%s\"},\"status\":{\"phase\":\"Running\",\"containerStatuses\":[{\"name\":\"app\",\"restartCount\":20,\"lastState\":{\"terminated\":{\"finishedAt\":\"%s\"}}}]}},"+
+		"{\"metadata\":{\"name\":\"settled\",\"namespace\":\"ns\",\"creationTimestamp\":\"%s\"},\"status\":{\"phase\":\"Running\",\"containerStatuses\":[{\"name\":\"app\",\"restartCount\":20,\"lastState\":{\"terminated\":{\"finishedAt\":\"%s\"}}}]}}]}",
+		old, old, recent, old, longAgo)
+	opts, err := parseArgs([]string{"get", "pods", "*", "--churning"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	joined := finalArgs(fr, "get", "pods")
+	if !strings.Contains(joined, " churning ") || strings.Contains(joined, " stable ") || strings.Contains(joined, " settled ") {
+		t.Fatalf("expected only the churning pod; calls=%v", fr.calls)
+	}
+}
+
+func TestPrintJSON_VersionWrapper(t *testing.T) {
+	matched := []matchedRef{{ns: "ns", name: "a", phase: "Running"}}
+	var buf bytes.Buffer
+	if err := printJSON(&buf, matched, false); err != nil {
+		t.Fatal(err)
+	}
+	var wrapped struct {
+		WildVersion string                   `json:"wildVersion"`
+		Items       []map[string]interface{} `json:"items"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &wrapped); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if wrapped.WildVersion != "1" || len(wrapped.Items) != 1 || wrapped.Items[0]["name"] != "a" {
+		t.Fatalf("unexpected wrapped output: %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := printJSON(&buf, matched, true); err != nil {
+		t.Fatal(err)
+	}
+	var bare []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &bare); err != nil {
+		t.Fatalf("expected bare JSON array: %v\n%s", err, buf.String())
+	}
+	if len(bare) != 1 || bare[0]["namespace"] != "ns" || strings.Contains(buf.String(), "wildVersion") {
+		t.Fatalf("unexpected bare output: %s", buf.String())
+	}
+
+	buf.Reset()
+	printMetrics(&buf, CLIOptions{Resource: "pods", Bare: true}, matched)
+	if strings.Contains(buf.String(), "kube_wild_format_version") {
+		t.Fatalf("--bare should drop the metrics version header: %s", buf.String())
+	}
+}
+
+func TestNativeSelector_ComposesWithWildLabelFilter(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	// The server already applied app=web; wild must further filter tier=front-*
+	fr.outputs["get pods -o json -n ns -l app=web"] = "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"web-front\",\"namespace\":\"ns\",\"labels\":{\"app\":\"web\",\"tier\":\"front-1\"}}}," +
+		"{\"metadata\":{\"name\":\"web-back\",\"namespace\":\"ns\",\"labels\":{\"app\":\"web\",\"tier\":\"back\"}}}]}"
+	opts, err := parseArgs([]string{"get", "pods", "-n", "ns", "-l", "app=web", "--label", "tier=front-*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(opts.Include, []string{"*"}) {
+		t.Fatalf("selector value must not become a name pattern, got %v", opts.Include)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(fr.calls) == 0 || strings.Join(fr.calls[0], " ") != "get pods -o json -n ns -l app=web" {
+		t.Fatalf("expected discovery with native selector; calls=%v", fr.calls)
+	}
+	joined := finalArgs(fr, "get", "pods")
+	if !strings.Contains(joined, " web-front ") || strings.Contains(joined, " web-back ") {
+		t.Fatalf("expected glob to keep only web-front; calls=%v", fr.calls)
+	}
+	if strings.Contains(joined, " -l ") {
+		t.Fatalf("selector must not be forwarded with explicit names; calls=%v", fr.calls)
+	}
+}
+
+func TestCompactDescribe_StripsManagedFields(t *testing.T) {
+	describeOut := "Name:         web\n" +
+		"Namespace:    ns\n" +
+		"Metadata:\n" +
+		"  Generation:  1\n" +
+		"  Managed Fields:\n" +
+		"    API Version:  example.com/v1\n" +
+		"    Fields Type:  FieldsV1\n" +
+		"    fieldsV1:\n" +
+		"      f:spec:\n" +
+		"\n" +
+		"    Manager:      kubectl\n" +
+		"  Resource Version:  42\n" +
+		"Spec:\n" +
+		"  Replicas:  1\n"
+	want := "Name:         web\n" +
+		"Namespace:    ns\n" +
+		"Metadata:\n" +
+		"  Generation:  1\n" +
+		"  Resource Version:  42\n" +
+		"Spec:\n" +
+		"  Replicas:  1\n"
+	if got := stripManagedFields(describeOut); got != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["describe widgets.example.com web"] = describeOut
+	var buf bytes.Buffer
+	opts := CLIOptions{Verb: VerbDescribe, Resource: "widgets.example.com", Include: []string{"web"}, Mode: MatchGlob}
+	if err := runVerbPerScope(compactDescribeRunner{Runner: fr, out: &buf}, "describe", opts, []matchedRef{{name: "web"}}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != want {
+		t.Fatalf("unexpected compact describe output:\n%s", buf.String())
+	}
+}
+
+// flakyRunner fails the first failures captures of any call (or, with verb set,
+// of calls to that verb) with the given stderr.
+type flakyRunner struct {
+	fakeRunner
+	failures int
+	stderr   string
+	verb     string
+}
+
+func (f *flakyRunner) CaptureKubectl(args []string) ([]byte, []byte, error) {
+	if f.failures > 0 && (f.verb == "" || (len(args) > 0 && args[0] == f.verb)) {
+		f.failures--
+		f.calls = append(f.calls, append([]string{}, args...))
+		return nil, []byte(f.stderr), exitCodeErr{code: 1}
+	}
+	return f.fakeRunner.CaptureKubectl(args)
+}
+
+func TestRunMutating_RetriesOnlyOnConflict(t *testing.T) {
+	origSleep := retrySleep
+	defer func() { retrySleep = origSleep }()
+	retrySleep = func(time.Duration) {}
+	args := []string{"patch", "pods", "web", "--type=merge", "-p", "{}"}
+
+	conflict := `Error from server (Conflict): Operation cannot be fulfilled on pods "web": the object has been modified; please apply your changes to the latest version and try again`
+	fr := &flakyRunner{fakeRunner: fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}, failures: 1, stderr: conflict}
+	if err := runMutating(fr, args, 2); err != nil {
+		t.Fatalf("expected success after retry, got %v", err)
+	}
+	if len(fr.calls) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(fr.calls))
+	}
+
+	fr = &flakyRunner{fakeRunner: fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}, failures: 1, stderr: `Error from server (Forbidden): pods "web" is forbidden`}
+	if err := runMutating(fr, args, 2); err == nil {
+		t.Fatal("expected non-conflict error to be returned without retry")
+	}
+	if len(fr.calls) != 1 {
+		t.Fatalf("expected a single attempt for non-conflict errors, got %d", len(fr.calls))
+	}
+}
+
+func TestRestartPolicy_JobVsDeploymentPods(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"migrate-x7k2p\",\"namespace\":\"ns\"},\"spec\":{\"restartPolicy\":\"OnFailure\"}}," +
+		"{\"metadata\":{\"name\":\"web-5d9c8-abcde\",\"namespace\":\"ns\"},\"spec\":{\"restartPolicy\":\"Always\"}}]}"
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"*"}, Mode: MatchGlob, RestartPolicy: "OnFailure"}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	joined := finalArgs(fr, "get", "pods")
+	if !strings.Contains(joined, " migrate-x7k2p ") || strings.Contains(joined, " web-5d9c8-abcde ") {
+		t.Fatalf("unexpected --restart-policy result; calls=%v", fr.calls)
+	}
+}
+
+func TestNamesStatus_PrintsPhaseAndRestarts(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"api-1\",\"namespace\":\"prod\"},\"status\":{\"phase\":\"Running\",\"containerStatuses\":[{\"restartCount\":3},{\"restartCount\":1}]}}," +
+		"{\"metadata\":{\"name\":\"api-2\",\"namespace\":\"prod\"},\"status\":{\"phase\":\"Pending\"}}]}"
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"api-*"}, Mode: MatchGlob, NamesStatus: true}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	printNamesStatus(&buf, matched, "")
+	if want := "prod/api-1\tRunning\t4\nprod/api-2\tPending\t0\n"; buf.String() != want {
+		t.Fatalf("unexpected --names-status output:\n%q\nwant:\n%q", buf.String(), want)
+	}
+	buf.Reset()
+	printNamesStatus(&buf, matched, ",")
+	if want := "prod/api-1,Running,4\nprod/api-2,Pending,0\n"; buf.String() != want {
+		t.Fatalf("unexpected --output-separator output: %q", buf.String())
+	}
+}
+
+func TestAgeFilters_UseInjectedClockAndAsOf(t *testing.T) {
+	origNow := now
+	defer func() { now = origNow }()
+	now = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"old\",\"namespace\":\"ns\",\"creationTimestamp\":\"2025-06-01T09:00:00Z\"}}," +
+		"{\"metadata\":{\"name\":\"new\",\"namespace\":\"ns\",\"creationTimestamp\":\"2025-06-01T11:30:00Z\"}}]}"
+	names := func(opts CLIOptions) string {
+		matched, err := discoverMatched(fr, &opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out []string
+		for _, m := range matched {
+			out = append(out, m.name)
+		}
+		return strings.Join(out, ",")
+	}
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"*"}, Mode: MatchGlob, OlderThan: time.Hour}
+	if got := names(opts); got != "old" {
+		t.Fatalf("expected only old pod relative to injected clock, got %q", got)
+	}
+	// At 09:30 neither pod was an hour old yet
+	opts.AsOf = time.Date(2025, 6, 1, 9, 30, 0, 0, time.UTC)
+	if got := names(opts); got != "" {
+		t.Fatalf("expected no matches as of 09:30, got %q", got)
+	}
+}
+
+func TestManagedBy_ArgoVsKubectl(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get configmaps -o json"] = "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"app-config\",\"namespace\":\"ns\",\"managedFields\":[{\"manager\":\"argocd-controller\",\"operation\":\"Apply\"}]}}," +
+		"{\"metadata\":{\"name\":\"hand-config\",\"namespace\":\"ns\",\"managedFields\":[{\"manager\":\"kubectl-client-side-apply\",\"operation\":\"Update\"}]}}]}"
+	run := func(glob string) string {
+		fr.calls = nil
+		opts := CLIOptions{Verb: VerbGet, Resource: "configmaps", Include: []string{"*"}, Mode: MatchGlob, ManagedBy: []string{glob}}
+		if err := runCommand(fr, opts); err != nil {
+			t.Fatal(err)
+		}
+		return finalArgs(fr, "get", "configmaps")
+	}
+	if joined := run("argocd*"); !strings.Contains(joined, " app-config ") || strings.Contains(joined, " hand-config ") {
+		t.Fatalf("--managed-by argocd* mismatch: %s", joined)
+	}
+	if joined := run("kubectl*"); !strings.Contains(joined, " hand-config ") || strings.Contains(joined, " app-config ") {
+		t.Fatalf("--managed-by kubectl* mismatch: %s", joined)
+	}
+}
+
+func TestParseArgs_LogsVerbForcesPods(t *testing.T) {
+	opts, err := parseArgs([]string{"logs", "api-*", "-n", "prod", "--container-name", "app", "--", "--tail=10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Verb != VerbLogs || opts.Resource != "pods" {
+		t.Fatalf("expected logs on pods, got %s %s", opts.Verb, opts.Resource)
+	}
+	if len(opts.Include) != 1 || opts.Include[0] != "api-*" {
+		t.Fatalf("expected pattern api-*, got %v", opts.Include)
+	}
+	if !strings.Contains(strings.Join(opts.FinalFlags, " "), "-c app") {
+		t.Fatalf("expected -c app in final flags, got %v", opts.FinalFlags)
+	}
+	if len(opts.ExtraFinal) != 1 || opts.ExtraFinal[0] != "--tail=10" {
+		t.Fatalf("expected --tail=10 passthrough, got %v", opts.ExtraFinal)
+	}
+	opts, err = parseArgs([]string{"logs", "pods", "api-*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Resource != "pods" || len(opts.Include) != 1 || opts.Include[0] != "api-*" {
+		t.Fatalf("expected explicit pods resource to be accepted, got %s %v", opts.Resource, opts.Include)
+	}
+}
+
+func TestLogs_ContinuesPastFailingPod(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -n prod"] = discoveryJSON("api-1", "api-2")
+	fr.errs["logs api-1 -n prod -c app --tail=10"] = exitCodeErr{code: 1}
+	opts := CLIOptions{Verb: VerbLogs, Resource: "pods", Include: []string{"api-*"}, Mode: MatchGlob, BatchSize: 10,
+		Namespace: "prod", DiscoveryFlags: []string{"-n", "prod"}, FinalFlags: []string{"-c", "app"}, ExtraFinal: []string{"--tail=10"}}
+	err := runCommand(fr, opts)
+	if err == nil {
+		t.Fatal("expected the failing pod's error to be reported")
+	}
+	var logCalls []string
+	for _, c := range fr.calls {
+		if len(c) > 0 && c[0] == "logs" {
+			logCalls = append(logCalls, strings.Join(c, " "))
+		}
+	}
+	want := []string{"logs api-1 -n prod -c app --tail=10", "logs api-2 -n prod -c app --tail=10"}
+	if strings.Join(logCalls, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected logs calls: %v", logCalls)
+	}
+}
+
+func TestPager_ReceivesKubectlOutput(t *testing.T) {
+	origTTY := stdoutIsTerminal
+	defer func() { stdoutIsTerminal = origTTY }()
+	stdoutIsTerminal = func() bool { return true }
+	paged := filepath.Join(t.TempDir(), "paged.txt")
+	t.Setenv("PAGER", "cat > "+paged)
+
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = discoveryJSON("a1", "a2", "b1")
+	fr.outputs["get pods a1 a2"] = "NAME READY\na1 1/1\na2 1/1\n"
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"a*"}, Mode: MatchGlob, BatchSize: 10, Pager: true}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(paged)
+	if err != nil {
+		t.Fatalf("pager did not run: %v", err)
+	}
+	if string(got) != "NAME READY\na1 1/1\na2 1/1\n" {
+		t.Fatalf("pager received unexpected output: %q", got)
+	}
+
+	// Not a TTY: output is not paged
+	stdoutIsTerminal = func() bool { return false }
+	os.Remove(paged)
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(paged); err == nil {
+		t.Fatal("pager must not run when stdout is not a terminal")
+	}
+}
+
+func TestLogsPrefixed_DeterministicOrder(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["logs api-2 -n prod --tail=2"] = "b1\nb2\n"
+	fr.outputs["logs api-1 -n prod --tail=2"] = "a1\na2"
+	matched := []matchedRef{{ns: "prod", name: "api-2"}, {ns: "prod", name: "api-1"}}
+	opts := CLIOptions{Verb: VerbLogs, Resource: "pods", NoColor: true, ExtraFinal: []string{"--tail=2"}}
+	var buf bytes.Buffer
+	if err := runLogsPrefixed(fr, opts, matched, &buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "prod/api-2 b1\nprod/api-2 b2\nprod/api-1 a1\nprod/api-1 a2\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected prefixed logs:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
+
+// streamingRunner emits each pod's lines in small chunks through StreamKubectl and
+// is safe for concurrent use.
+type streamingRunner struct {
+	fakeRunner
+	mu sync.Mutex
+}
+
+func (s *streamingRunner) StreamKubectl(args []string, stdout io.Writer) error {
+	s.mu.Lock()
+	s.calls = append(s.calls, append([]string{}, args...))
+	s.mu.Unlock()
+	for i := 0; i < 50; i++ {
+		line := fmt.Sprintf("%s line %d\n", args[1], i)
+		// split mid-line to exercise line buffering
+		stdout.Write([]byte(line[:3]))
+		stdout.Write([]byte(line[3:]))
+	}
+	return nil
+}
+
+func TestLogsPrefixed_FollowInterleavesWholeLines(t *testing.T) {
+	sr := &streamingRunner{fakeRunner: fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}}
+	matched := []matchedRef{{ns: "ns", name: "web-1"}, {ns: "ns", name: "web-2"}, {ns: "ns", name: "web-3"}}
+	opts := CLIOptions{Verb: VerbLogs, Resource: "pods", NoColor: true, FinalFlags: []string{"-f"}}
+	var buf bytes.Buffer
+	if err := runLogsPrefixed(sr, opts, matched, &buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 150 {
+		t.Fatalf("expected 150 lines, got %d", len(lines))
+	}
+	for _, ln := range lines {
+		var pod, pod2 string
+		var n int
+		if _, err := fmt.Sscanf(ln, "ns/%s %s line %d", &pod, &pod2, &n); err != nil || pod != pod2 {
+			t.Fatalf("line has mismatched or broken prefix: %q", ln)
+		}
+	}
+}
+
+func TestRestartDelta_FromSnapshot(t *testing.T) {
+	pod := func(name string, restarts int) string {
+		return fmt.Sprintf(`{"metadata":{"name":%q,"namespace":"ns"},"status":{"containerStatuses":[{"restartCount":%d}]}}`, name, restarts)
+	}
+	snapshot := filepath.Join(t.TempDir(), "before.json")
+	if err := os.WriteFile(snapshot, []byte(`{"items":[`+pod("looping", 2)+","+pod("stable", 4)+`]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = `{"items":[` + pod("looping", 5) + "," + pod("stable", 4) + "]}"
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"*"}, Mode: MatchGlob, RestartDelta: 2, FromSnapshot: snapshot}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	joined := finalArgs(fr, "get", "pods")
+	if !strings.Contains(joined, " looping ") || strings.Contains(joined, " stable ") {
+		t.Fatalf("unexpected --restart-delta result; calls=%v", fr.calls)
+	}
+	if _, err := parseArgs([]string{"get", "pods", "*", "--restart-delta", "2"}); err == nil {
+		t.Fatal("expected error for --restart-delta without --from-snapshot")
+	}
+}
+
+func TestReadyColumn_TwoOfThree(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = `{"items":[{"metadata":{"name":"web","namespace":"ns"},"status":{"phase":"Running","containerStatuses":[` +
+		`{"name":"app","ready":true},{"name":"sidecar","ready":true},{"name":"proxy","ready":false}]}}]}`
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"*"}, Mode: MatchGlob, JSON: true}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matched) != 1 || readyColumn(matched[0]) != "2/3" {
+		t.Fatalf("expected READY 2/3, got %+v", matched)
+	}
+	var buf bytes.Buffer
+	if err := printJSON(&buf, matched, true); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"ready": "2/3"`) {
+		t.Fatalf("expected ready column in JSON output: %s", buf.String())
+	}
+}
+
+func TestMatchExact_LiteralNames(t *testing.T) {
+	m := Matcher{Mode: MatchExact, Includes: []string{"web[0]"}}
+	if !m.Matches("web[0]") {
+		t.Fatal("expected literal match for web[0]")
+	}
+	if m.Matches("web0") || m.Matches("web[0]-x") {
+		t.Fatal("exact mode must not interpret glob metacharacters or match prefixes")
+	}
+	ci := Matcher{Mode: MatchExact, Includes: []string{"Web-*"}, IgnoreCase: true}
+	if !ci.Matches("web-*") || ci.Matches("web-1") {
+		t.Fatal("expected case-insensitive literal match")
+	}
+	if _, err := parseArgs([]string{"get", "pods", "--exact"}); err == nil {
+		t.Fatal("expected error for --exact without a name")
+	}
+}
+
+func TestReadyFlappedWithin(t *testing.T) {
+	origNow := now
+	defer func() { now = origNow }()
+	now = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+	pod := func(name, transition string) string {
+		return fmt.Sprintf(`{"metadata":{"name":%q,"namespace":"ns"},"status":{"phase":"Running","conditions":[`+
+			`{"type":"PodScheduled","lastTransitionTime":"2025-06-01T11:59:30Z"},`+
+			`{"type":"Ready","status":"True","lastTransitionTime":%q}]}}`, name, transition)
+	}
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = `{"items":[` + pod("flapped", "2025-06-01T11:59:00Z") + "," + pod("steady", "2025-06-01T10:00:00Z") + "]}"
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"*"}, Mode: MatchGlob, ReadyFlappedWithin: 10 * time.Minute}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	joined := finalArgs(fr, "get", "pods")
+	if !strings.Contains(joined, " flapped ") || strings.Contains(joined, " steady ") {
+		t.Fatalf("unexpected --ready-flapped-within result; calls=%v", fr.calls)
+	}
+}
+
+func TestParseAgeDuration_DaysAndWeeks(t *testing.T) {
+	cases := map[string]time.Duration{
+		"7d":    7 * 24 * time.Hour,
+		"2w":    14 * 24 * time.Hour,
+		"36h":   36 * time.Hour,
+		"90m":   90 * time.Minute,
+		"1h30m": 90 * time.Minute,
+		"1d12h": 36 * time.Hour,
+	}
+	for in, want := range cases {
+		got, err := parseAgeDuration(in)
+		if err != nil || got != want {
+			t.Errorf("parseAgeDuration(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"7x", "d", "", "1.2.3d"} {
+		if _, err := parseAgeDuration(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+	opts, err := parseArgs([]string{"get", "pods", "--older-than", "7d", "--younger-than", "2w"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.OlderThan != 7*24*time.Hour || opts.YoungerThan != 14*24*time.Hour {
+		t.Fatalf("unexpected parsed ages: %v %v", opts.OlderThan, opts.YoungerThan)
+	}
+	if _, err := parseArgs([]string{"get", "pods", "--older-than", "7x"}); err == nil {
+		t.Fatal("expected error for --older-than 7x")
+	}
+}
+
+func TestRunVerbPerScope_StableNamespaceOrder(t *testing.T) {
+	clearResourceCaches()
+	matched := []matchedRef{
+		{ns: "zeta", name: "b"}, {ns: "alpha", name: "z"}, {ns: "mid", name: "m"},
+		{ns: "alpha", name: "a"}, {ns: "zeta", name: "a"}, {ns: "beta", name: "x"},
+	}
+	want := []string{
+		"describe pods a z -n alpha",
+		"describe pods x -n beta",
+		"describe pods m -n mid",
+		"describe pods a b -n zeta",
+	}
+	for run := 0; run < 20; run++ {
+		fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+		fr.outputs["api-resources -o name --verbs=list --namespaced=true"] = "pods\n"
+		opts := CLIOptions{Verb: VerbDescribe, Resource: "pods", AllNamespaces: true, BatchSize: 10}
+		if err := runVerbPerScope(fr, "describe", opts, append([]matchedRef(nil), matched...)); err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, c := range fr.calls {
+			if c[0] == "describe" {
+				got = append(got, strings.Join(c, " "))
+			}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("run %d: unexpected order:\n%v\nwant:\n%v", run, got, want)
+		}
+	}
+}
+
+func TestTerminating_TakesPrecedenceOverPhase(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = `{"items":[` +
+		`{"metadata":{"name":"wedged","namespace":"ns","deletionTimestamp":"2025-06-01T10:00:00Z"},"status":{"phase":"Running","containerStatuses":[{"state":{"running":{}}}]}},` +
+		`{"metadata":{"name":"fine","namespace":"ns"},"status":{"phase":"Running","containerStatuses":[{"state":{"running":{}}}]}}]}`
+	run := func(opts CLIOptions) string {
+		fr.calls = nil
+		if err := runCommand(fr, opts); err != nil {
+			t.Fatal(err)
+		}
+		return finalArgs(fr, "get", "pods")
+	}
+	base := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"*"}, Mode: MatchGlob}
+	opts := base
+	opts.Terminating = true
+	if joined := run(opts); !strings.Contains(joined, " wedged ") || strings.Contains(joined, " fine ") {
+		t.Fatalf("--terminating mismatch: %s", joined)
+	}
+	opts = base
+	opts.PodStatuses = []string{"Terminating"}
+	if joined := run(opts); !strings.Contains(joined, " wedged ") || strings.Contains(joined, " fine ") {
+		t.Fatalf("--pod-status Terminating mismatch: %s", joined)
+	}
+	opts = base
+	opts.PodStatuses = []string{"Running"}
+	if joined := run(opts); strings.Contains(joined, " wedged ") || !strings.Contains(joined, " fine ") {
+		t.Fatalf("terminating pod must not count as Running: %s", joined)
+	}
+}
+
+func TestNameCollisions_KeepsNamesInSeveralNamespaces(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get services -o json -A"] = `{"items":[` +
+		`{"metadata":{"name":"web","namespace":"prod"}},` +
+		`{"metadata":{"name":"api","namespace":"prod"}},` +
+		`{"metadata":{"name":"web","namespace":"staging"}}]}`
+	opts := CLIOptions{Verb: VerbGet, Resource: "services", Include: []string{"*"}, Mode: MatchGlob,
+		AllNamespaces: true, DiscoveryFlags: []string{"-A"}, NameCollisions: true}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, m := range matched {
+		got = append(got, m.ns+"/"+m.name)
+	}
+	if strings.Join(got, ",") != "prod/web,staging/web" {
+		t.Fatalf("expected only web entries, got %v", got)
+	}
+	if _, err := parseArgs([]string{"get", "svc", "--name-collisions"}); err == nil {
+		t.Fatal("expected error for --name-collisions without -A")
+	}
+}
+
+func TestInitContainerCrashLoop_CountsTowardsFilters(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = `{"items":[` +
+		`{"metadata":{"name":"broken-migration","namespace":"ns"},"status":{"phase":"Running",` +
+		`"initContainerStatuses":[{"name":"migrate","restartCount":7,"state":{"waiting":{"reason":"CrashLoopBackOff"}}}],` +
+		`"containerStatuses":[{"name":"app","ready":true,"state":{"running":{}}}]}},` +
+		`{"metadata":{"name":"healthy","namespace":"ns"},"status":{"phase":"Running",` +
+		`"initContainerStatuses":[{"name":"migrate","state":{"terminated":{"reason":"Completed"}}}],` +
+		`"containerStatuses":[{"name":"app","ready":true,"state":{"running":{}}}]}}]}`
+	names := func(opts CLIOptions) string {
+		opts.Verb, opts.Resource, opts.Include, opts.Mode = VerbGet, "pods", []string{"*"}, MatchGlob
+		matched, err := discoverMatched(fr, &opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out []string
+		for _, m := range matched {
+			out = append(out, m.name)
+		}
+		return strings.Join(out, ",")
+	}
+	if got := names(CLIOptions{ReasonFilters: []string{"CrashLoopBackOff"}}); got != "broken-migration" {
+		t.Fatalf("--reason CrashLoopBackOff: got %q", got)
+	}
+	if got := names(CLIOptions{RestartExpr: ">0"}); got != "broken-migration" {
+		t.Fatalf("--restarts >0: got %q", got)
+	}
+	if got := names(CLIOptions{ReasonFilters: []string{"CrashLoopBackOff"}, ContainerScope: "init:migrate"}); got != "broken-migration" {
+		t.Fatalf("--container-name init:migrate: got %q", got)
+	}
+	if got := names(CLIOptions{ReasonFilters: []string{"CrashLoopBackOff"}, ContainerScope: "app"}); got != "" {
+		t.Fatalf("--container-name app must not see init reasons: got %q", got)
+	}
+	if got := names(CLIOptions{Unhealthy: true}); got != "broken-migration" {
+		t.Fatalf("a completed init container must not make a pod unhealthy: got %q", got)
+	}
+}
+
+func TestCountBy_Node(t *testing.T) {
+	pod := func(name, node string) string {
+		return fmt.Sprintf(`{"metadata":{"name":%q,"namespace":"ns"},"spec":{"nodeName":%q}}`, name, node)
+	}
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = `{"items":[` + pod("a", "node-2") + "," + pod("b", "node-1") + "," + pod("c", "node-2") + "," + pod("d", "") + "]}"
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"*"}, Mode: MatchGlob, CountBy: "node"}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	printCountBy(&buf, opts, matched)
+	if want := "Count by node:\n(none): 1\nnode-1: 1\nnode-2: 2\n"; buf.String() != want {
+		t.Fatalf("unexpected --count-by output:\n%q\nwant:\n%q", buf.String(), want)
+	}
+	buf.Reset()
+	opts.FinalFlags = []string{"--no-headers"}
+	printCountBy(&buf, opts, matched)
+	if strings.Contains(buf.String(), "Count by") {
+		t.Fatalf("--no-headers must drop the title: %q", buf.String())
+	}
+	if _, err := parseArgs([]string{"get", "pods", "--count-by", "color"}); err == nil {
+		t.Fatal("expected error for unknown --count-by field")
+	}
+}
+
+func TestEvicted_StatusReason(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = `{"items":[` +
+		`{"metadata":{"name":"evicted-1","namespace":"ns"},"status":{"phase":"Failed","reason":"Evicted","message":"The node was low on resource: memory."}},` +
+		`{"metadata":{"name":"crashed","namespace":"ns"},"status":{"phase":"Failed","containerStatuses":[{"state":{"terminated":{"reason":"Error"}}}]}},` +
+		`{"metadata":{"name":"ok","namespace":"ns"},"status":{"phase":"Running","containerStatuses":[{"ready":true,"state":{"running":{}}}]}}]}`
+	names := func(opts CLIOptions) string {
+		opts.Verb, opts.Resource, opts.Include, opts.Mode = VerbGet, "pods", []string{"*"}, MatchGlob
+		matched, err := discoverMatched(fr, &opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out []string
+		for _, m := range matched {
+			out = append(out, m.name)
+		}
+		return strings.Join(out, ",")
+	}
+	opts, err := parseArgs([]string{"get", "pods", "--evicted"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := names(CLIOptions{PodStatuses: opts.PodStatuses}); got != "evicted-1" {
+		t.Fatalf("--evicted: got %q", got)
+	}
+	if got := names(CLIOptions{Unhealthy: true}); got != "evicted-1,crashed" {
+		t.Fatalf("--unhealthy must include evicted pods: got %q", got)
+	}
+}
+
+func TestExclude_LiteralNamePushedToFieldSelector(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json --field-selector metadata.name!=exact-name"] = discoveryJSON("api-1", "api-2")
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"*"}, Exclude: []string{"exact-name", "api-2*"}, Mode: MatchGlob}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fr.calls) == 0 || strings.Join(fr.calls[len(fr.calls)-1], " ") != "get pods -o json --field-selector metadata.name!=exact-name" {
+		t.Fatalf("expected field selector for the literal exclude only; calls=%v", fr.calls)
+	}
+	if len(matched) != 1 || matched[0].name != "api-1" {
+		t.Fatalf("glob exclude must still apply client-side, got %+v", matched)
+	}
+	opts = CLIOptions{Mode: MatchGlob, Exclude: []string{"web"}, DiscoveryFlags: []string{"--field-selector=status.phase=Running"}}
+	if sel := excludeFieldSelector(opts); sel != nil {
+		t.Fatalf("must not override a user field selector, got %v", sel)
+	}
+	opts = CLIOptions{Mode: MatchContains, Exclude: []string{"web"}}
+	if sel := excludeFieldSelector(opts); sel != nil {
+		t.Fatalf("contains excludes are not exact names, got %v", sel)
+	}
+}
+
+func TestConnectionFlags_ThreadedIntoAPIResources(t *testing.T) {
+	opts, err := parseArgs([]string{"get", "pods", "--context", "staging", "--kubeconfig=/tmp/kc", "api-*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, inc := range opts.Include {
+		if inc == "staging" {
+			t.Fatalf("--context value must not become a pattern: %v", opts.Include)
+		}
+	}
+	want := []string{"--context", "staging", "--kubeconfig=/tmp/kc"}
+	if got := connectionFlags(opts.DiscoveryFlags); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("connectionFlags: got %v want %v", got, want)
+	}
+	if !containsFlag(opts.FinalFlags, "staging") {
+		t.Fatalf("--context must reach the final command: %v", opts.FinalFlags)
+	}
+
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["api-resources -o name --verbs=list --namespaced=true --context staging"] = "widgets.example.com\n"
+	fr.outputs["api-resources -o name --verbs=list --namespaced=false --context staging"] = "nodes\n"
+	ns, err := isResourceNamespaced(fr, "widgets.example.com", []string{"--context", "staging"})
+	if err != nil || !ns {
+		t.Fatalf("expected namespaced via staging context, got %v err=%v", ns, err)
+	}
+	for _, c := range fr.calls {
+		if c[0] == "api-resources" && !containsFlag(c, "staging") {
+			t.Fatalf("api-resources call without --context: %v", c)
+		}
+	}
+}
+
+func TestPreviewAsList_Limit(t *testing.T) {
+	var matched []matchedRef
+	for i := 0; i < 100; i++ {
+		matched = append(matched, matchedRef{name: fmt.Sprintf("pod-%03d", i)})
+	}
+	var buf bytes.Buffer
+	previewAsList(&buf, CLIOptions{Resource: "pods", NoColor: true, PreviewLimit: 10}, matched)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 12 {
+		t.Fatalf("expected header + 10 names + more line, got %d lines:\n%s", len(lines), buf.String())
+	}
+	if lines[0] != "About to delete 100 pods:" || lines[10] != "pod-009" || lines[11] != "... and 90 more" {
+		t.Fatalf("unexpected preview:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	previewAsList(&buf, CLIOptions{Resource: "pods", NoColor: true}, matched)
+	if strings.Contains(buf.String(), "more") || strings.Count(buf.String(), "\n") != 101 {
+		t.Fatalf("--preview-limit 0 must list every match")
+	}
+}
+
+// listFileRunner records the List written for `kubectl get -f FILE`.
+type listFileRunner struct {
+	*fakeRunner
+	list []byte
+}
+
+func (r *listFileRunner) RunKubectl(args []string) error {
+	if len(args) > 2 && args[0] == "get" && args[1] == "-f" {
+		r.list, _ = os.ReadFile(args[2])
+	}
+	return r.fakeRunner.RunKubectl(args)
+}
+
+func TestAllNamespacesSingleTable_ReusesDiscoveryJSON(t *testing.T) {
+	list := `{"items": [
+		{"metadata":{"name":"web-1","namespace":"ns2"},"spec":{"nodeName":"n1"}},
+		{"metadata":{"name":"db-1","namespace":"ns1"}},
+		{"metadata":{"name":"web-2","namespace":"ns1"}}
+	]}`
+	fr := &fakeRunner{outputs: map[string]string{"get pods -o json -A": list}, errs: map[string]error{}}
+	r := &listFileRunner{fakeRunner: fr}
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"web-*"}, Mode: MatchGlob, AllNamespaces: true}
+	opts.DiscoveryFlags = []string{"-A"}
+	if err := runCommand(r, opts); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range fr.calls {
+		if strings.Join(c, " ") == "get pods -A -o json" {
+			t.Fatalf("single table must not list the resource a second time; calls=%v", fr.calls)
+		}
+	}
+	var got struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(r.list, &got); err != nil {
+		t.Fatalf("invalid List %q: %v", r.list, err)
+	}
+	want := []string{
+		`{"metadata":{"name":"web-2","namespace":"ns1"}}`,
+		`{"metadata":{"name":"web-1","namespace":"ns2"},"spec":{"nodeName":"n1"}}`,
+	}
+	if len(got.Items) != len(want) {
+		t.Fatalf("expected %d items, got %s", len(want), r.list)
+	}
+	for i, w := range want {
+		if string(got.Items[i]) != w {
+			t.Fatalf("item %d: got %s want %s", i, got.Items[i], w)
+		}
+	}
+}
+
+func TestIgnoreCase_UnicodeLabelValues(t *testing.T) {
+	if got := toLowerFast("GRÜN"); got != "grün" {
+		t.Fatalf("toLowerFast must fold multibyte uppercase, got %q", got)
+	}
+	if got := toLowerFast("grün"); got != "grün" {
+		t.Fatalf("toLowerFast changed a lowercase value: %q", got)
+	}
+	lf := prepareLabelFilter(LabelFilter{Key: "color", Pattern: "GRÜN", Mode: LabelGlob}, true)
+	if !labelValueMatches("grün", lf) || !labelValueMatches("Grün", lf) {
+		t.Fatalf("--ignore-case must fold German label values")
+	}
+	lf = prepareLabelFilter(LabelFilter{Key: "region", Pattern: "çağ*", Mode: LabelGlob}, true)
+	if !labelValueMatches("ÇAĞ-1", lf) {
+		t.Fatalf("--ignore-case must fold Turkish label values")
+	}
+	lf = prepareLabelFilter(LabelFilter{Key: "region", Pattern: "ÇAĞ", Mode: LabelContains}, false)
+	if labelValueMatches("çağ", lf) {
+		t.Fatalf("without --ignore-case values must compare case-sensitively")
+	}
+	m := Matcher{AnnotationFilters: []LabelFilter{prepareLabelFilter(LabelFilter{Key: "owner", Pattern: "^JÖRG$", Mode: LabelRegex}, true)}}
+	if !m.AnnotationsAllowed(map[string]string{"owner": "jörg"}) {
+		t.Fatalf("--ignore-case must apply to regex annotation values")
+	}
+}
+
+// pipeRunner serves discovery through PipeKubectl like ExecRunner does.
+type pipeRunner struct {
+	*fakeRunner
+	closeErr error
+}
+
+func (r *pipeRunner) PipeKubectl(args []string) (io.ReadCloser, error) {
+	r.calls = append(r.calls, append([]string{"pipe"}, args...))
+	return pipeBody{strings.NewReader(r.outputs[strings.Join(args, " ")]), r.closeErr}, nil
+}
+
+type pipeBody struct {
+	io.Reader
+	err error
+}
+
+func (b pipeBody) Close() error { return b.err }
+
+func TestDiscoverNames_StreamsThroughPipe(t *testing.T) {
+	list := `{"apiVersion":"v1","kind":"List","metadata":{"resourceVersion":""},"items":[
+		{"metadata":{"name":"a","namespace":"ns1"},"status":{"phase":"Running"}},
+		{"metadata":{"name":"b","namespace":"ns2"}}]}`
+	fr := &fakeRunner{outputs: map[string]string{"get pods -o json -A": list}, errs: map[string]error{}}
+	r := &pipeRunner{fakeRunner: fr}
+	refs, err := discoverNames(r, "pods", []string{"-A"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refs) != 2 || refs[0].Name != "a" || refs[0].PodPhase != "Running" || refs[1].Namespace != "ns2" {
+		t.Fatalf("unexpected refs: %+v", refs)
+	}
+	if string(refs[1].Raw) != `{"metadata":{"name":"b","namespace":"ns2"}}` {
+		t.Fatalf("keepRaw must retain item JSON, got %s", refs[1].Raw)
+	}
+	for _, c := range fr.calls {
+		if c[0] == "get" {
+			t.Fatalf("discovery must not buffer via CaptureKubectl; calls=%v", fr.calls)
+		}
+	}
+	if refs, _ := discoverNames(r, "pods", []string{"-A"}, false); refs[0].Raw != nil {
+		t.Fatalf("raw JSON must not be kept unless asked")
+	}
+
+	r.closeErr = errors.New("error: the server doesn't have a resource type \"pods\"")
+	fr.outputs["get pods -o json -A"] = ""
+	if _, err := discoverNames(r, "pods", []string{"-A"}, false); err == nil || !strings.Contains(err.Error(), "resource type") {
+		t.Fatalf("expected kubectl error from Close, got %v", err)
+	}
+	if _, err := decodeK8sList(strings.NewReader(`{"items":null}`), false); err != nil {
+		t.Fatalf("null items: %v", err)
+	}
+	if _, err := decodeK8sList(strings.NewReader(`{"kind":"List"}`), false); err == nil {
+		t.Fatalf("expected error for a list without items")
+	}
+}
+
+func TestShowOwner_ControlledByColumn(t *testing.T) {
+	list := `{"items":[
+		{"metadata":{"name":"web-abc-1","namespace":"prod","ownerReferences":[{"kind":"ReplicaSet","name":"web-abc"}]}},
+		{"metadata":{"name":"debug","namespace":"dev"}}]}`
+	refs, err := parseK8sListStreaming([]byte(list))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// kept order is namespace/name sorted: dev/debug, prod/web-abc-1
+	rows := [][]string{refs[1].Owners, refs[0].Owners}
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get -f list.json -A"] = "NAMESPACE   NAME        READY   STATUS\n" +
+		"dev         debug       1/1     Running\n" +
+		"prod        web-abc-1   1/1     Running\n"
+	var buf bytes.Buffer
+	if err := writeOwnerTable(&buf, fr, []string{"get", "-f", "list.json", "-A"}, rows, true); err != nil {
+		t.Fatal(err)
+	}
+	want := "NAMESPACE   NAME        READY   STATUS    CONTROLLED-BY\n" +
+		"dev         debug       1/1     Running   <none>\n" +
+		"prod        web-abc-1   1/1     Running   ReplicaSet/web-abc\n"
+	if buf.String() != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	if _, err := parseArgs([]string{"get", "pods", "*", "--show-owner"}); err == nil {
+		t.Fatalf("--show-owner without -A must error")
+	}
+	if _, err := parseArgs([]string{"get", "pods", "*", "-A", "--show-owner", "-o", "yaml"}); err == nil {
+		t.Fatalf("--show-owner with -o yaml must error")
+	}
+	opts, err := parseArgs([]string{"get", "pods", "*", "-A", "--show-owner", "-o", "wide"})
+	if err != nil {
+		t.Fatalf("--show-owner with -o wide: %v", err)
+	}
+	if !reflect.DeepEqual(opts.Include, []string{"*"}) || outputFormat(opts.FinalFlags) != "wide" {
+		t.Fatalf("-o value must not become a pattern: include=%v final=%v", opts.Include, opts.FinalFlags)
+	}
+}
+
+func TestLabelFilters_ExactPushedToSelector(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	list := `{"items":[
+		{"metadata":{"name":"web-1","labels":{"app":"web","tier":"frontend-a"}}},
+		{"metadata":{"name":"web-2","labels":{"app":"web","tier":"backend"}}}]}`
+	fr.outputs["get pods -o json -l app=web"] = list
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"*"}, Mode: MatchGlob,
+		LabelFilters: []LabelFilter{{Key: "app", Pattern: "web", Mode: LabelGlob}, {Key: "tier", Pattern: "frontend-*", Mode: LabelGlob}}}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(fr.calls[len(fr.calls)-1], " "); got != "get pods -o json -l app=web" {
+		t.Fatalf("expected exact label pushed as selector, got %q", got)
+	}
+	if len(matched) != 1 || matched[0].name != "web-1" {
+		t.Fatalf("glob label filter must still apply client-side, got %+v", matched)
+	}
+
+	cases := []struct {
+		name string
+		opts CLIOptions
+	}{
+		{"ignore-case", CLIOptions{IgnoreCase: true, LabelFilters: []LabelFilter{{Key: "app", Pattern: "web"}}}},
+		{"user selector", CLIOptions{DiscoveryFlags: []string{"-l", "env=prod"}, LabelFilters: []LabelFilter{{Key: "app", Pattern: "web"}}}},
+		{"prefix mode", CLIOptions{LabelFilters: []LabelFilter{{Key: "app", Pattern: "web", Mode: LabelPrefix}}}},
+		{"repeated key", CLIOptions{LabelFilters: []LabelFilter{{Key: "app", Pattern: "web"}, {Key: "app", Pattern: "api"}}}},
+		{"not a label value", CLIOptions{LabelFilters: []LabelFilter{{Key: "app", Pattern: "a b"}}}},
+	}
+	for _, c := range cases {
+		if sel := labelSelectorPushdown(c.opts); sel != nil {
+			t.Errorf("%s: expected no selector, got %v", c.name, sel)
+		}
+	}
+}
+
+func TestUsesPVC_Filter(t *testing.T) {
+	list := `{"items":[
+		{"metadata":{"name":"db-0","namespace":"ns"},"spec":{"volumes":[{"name":"cfg","configMap":{"name":"db"}},{"name":"data","persistentVolumeClaim":{"claimName":"data-0"}}]}},
+		{"metadata":{"name":"cache-0","namespace":"ns"},"spec":{"volumes":[{"name":"data","persistentVolumeClaim":{"claimName":"cache-data-0"}}]}},
+		{"metadata":{"name":"web","namespace":"ns"},"spec":{"volumes":[{"name":"tmp","emptyDir":{}}]}}]}`
+	fr := &fakeRunner{outputs: map[string]string{"get pods -o json": list}, errs: map[string]error{}}
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"*"}, Mode: MatchGlob, UsesPVC: []string{"data-*"}}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matched) != 1 || matched[0].name != "db-0" {
+		t.Fatalf("expected only db-0 (mounts data-0), got %+v", matched)
+	}
+}
+
+func TestStatus_AnyResourcePhase(t *testing.T) {
+	list := `{"items":[
+		{"metadata":{"name":"data-0","namespace":"db"},"status":{"phase":"Bound"}},
+		{"metadata":{"name":"data-1","namespace":"db"},"status":{"phase":"Pending"}},
+		{"metadata":{"name":"old","namespace":"db"},"status":{"phase":"Lost"}}]}`
+	fr := &fakeRunner{outputs: map[string]string{"get pvc -o json -A": list}, errs: map[string]error{}}
+	opts, err := parseArgs([]string{"get", "pvc", "*", "--status", "pending", "-A"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matched) != 1 || matched[0].name != "data-1" {
+		t.Fatalf("expected only the Pending claim, got %+v", matched)
+	}
+}
+
+func TestUsesConfigMapAndSecret_Filter(t *testing.T) {
+	list := `{"items":[
+		{"metadata":{"name":"vol","namespace":"ns"},"spec":{"volumes":[{"name":"c","configMap":{"name":"app-config"}},{"name":"s","secret":{"secretName":"tls"}}]}},
+		{"metadata":{"name":"envfrom","namespace":"ns"},"spec":{"containers":[{"name":"main","envFrom":[{"configMapRef":{"name":"app-env"}},{"secretRef":{"name":"db-creds"}}]}]}},
+		{"metadata":{"name":"valuefrom","namespace":"ns"},"spec":{"initContainers":[{"name":"migrate","env":[{"name":"PW","valueFrom":{"secretKeyRef":{"name":"db-creds","key":"pw"}}}]}]}},
+		{"metadata":{"name":"plain","namespace":"ns"},"spec":{"containers":[{"name":"main","env":[{"name":"A","value":"b"}]}]}}]}`
+	names := func(opts CLIOptions) string {
+		fr := &fakeRunner{outputs: map[string]string{"get pods -o json": list}, errs: map[string]error{}}
+		opts.Verb, opts.Resource, opts.Include, opts.Mode = VerbGet, "pods", []string{"*"}, MatchGlob
+		matched, err := discoverMatched(fr, &opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out []string
+		for _, m := range matched {
+			out = append(out, m.name)
+		}
+		return strings.Join(out, ",")
+	}
+	if got := names(CLIOptions{UsesConfigMap: []string{"app-*"}}); got != "vol,envfrom" {
+		t.Fatalf("--uses-configmap app-*: got %q", got)
+	}
+	if got := names(CLIOptions{UsesSecret: []string{"tls"}}); got != "vol" {
+		t.Fatalf("--uses-secret tls (volume): got %q", got)
+	}
+	if got := names(CLIOptions{UsesSecret: []string{"db-*"}}); got != "envfrom,valuefrom" {
+		t.Fatalf("--uses-secret db-* (envFrom, valueFrom): got %q", got)
+	}
+}
+
+func TestMatcher_OwnersAllowed(t *testing.T) {
+	owners := []string{"ReplicaSet/web-abc", "Job/backup-1"}
+	cases := []struct {
+		kinds, names []string
+		want         bool
+	}{
+		{nil, nil, true},
+		{[]string{"job"}, nil, true},
+		{[]string{"StatefulSet"}, nil, false},
+		{nil, []string{"web-*"}, true},
+		{[]string{"Job"}, []string{"backup-*"}, true},
+		// AND across flags is per owner: no single owner is a Job named web-*
+		{[]string{"Job"}, []string{"web-*"}, false},
+		{[]string{"DaemonSet", "ReplicaSet"}, []string{"web-*"}, true},
+	}
+	for _, c := range cases {
+		m := Matcher{OwnerKinds: c.kinds, OwnerNames: c.names}
+		if got := m.OwnersAllowed(owners); got != c.want {
+			t.Errorf("kinds=%v names=%v: got %v want %v", c.kinds, c.names, got, c.want)
+		}
+	}
+	if (Matcher{OwnerKinds: []string{"Job"}}).OwnersAllowed(nil) {
+		t.Errorf("an object without owners must not pass --owner-kind")
+	}
+}
+
+func TestParseAgeDuration_AgoPhrases(t *testing.T) {
+	cases := map[string]time.Duration{
+		"3 days ago":     3 * 24 * time.Hour,
+		"2 hours ago":    2 * time.Hour,
+		"1 week ago":     7 * 24 * time.Hour,
+		"45 minutes ago": 45 * time.Minute,
+		"2  Hours  AGO":  2 * time.Hour,
+	}
+	for in, want := range cases {
+		got, err := parseAgeDuration(in)
+		if err != nil || got != want {
+			t.Errorf("parseAgeDuration(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for bad, msg := range map[string]string{
+		"2 months ago":     "ambiguous",
+		"3 days":           "expected",
+		"a day ago":        "whole number",
+		"1.5 hours ago":    "whole number",
+		"3 fortnights ago": "unknown unit",
+	} {
+		if _, err := parseAgeDuration(bad); err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("parseAgeDuration(%q): expected error containing %q, got %v", bad, msg, err)
+		}
+	}
+	opts, err := parseArgs([]string{"get", "pods", "--older-than", "3 days ago"})
+	if err != nil || opts.OlderThan != 72*time.Hour {
+		t.Fatalf("--older-than '3 days ago': %v %v", opts.OlderThan, err)
+	}
+	if _, err := parseArgs([]string{"get", "pods", "--older-than", "1 year ago"}); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected ambiguous error surfaced by --older-than, got %v", err)
+	}
+}
+
+func TestReadyContainers_Expr(t *testing.T) {
+	list := `{"items":[
+		{"metadata":{"name":"one-ready","namespace":"ns"},"status":{"phase":"Running","containerStatuses":[
+			{"name":"app","ready":true},{"name":"proxy","ready":false},{"name":"log","ready":false}]}},
+		{"metadata":{"name":"three-ready","namespace":"ns"},"status":{"phase":"Running","containerStatuses":[
+			{"name":"app","ready":true},{"name":"proxy","ready":true},{"name":"log","ready":true}]}}]}`
+	fr := &fakeRunner{outputs: map[string]string{"get pods -o json": list}, errs: map[string]error{}}
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"*"}, Mode: MatchGlob, ReadyContainersExpr: "<2"}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matched) != 1 || matched[0].name != "one-ready" {
+		t.Fatalf("expected only one-ready, got %+v", matched)
+	}
+	if _, err := parseArgs([]string{"get", "pods", "--ready-containers", "few"}); err == nil {
+		t.Fatalf("expected error for a malformed expression")
+	}
+}
+
+func TestNamesOnly_PrintsNames(t *testing.T) {
+	matched := []matchedRef{{ns: "ns1", name: "web-1"}, {ns: "ns2", name: "web 2"}}
+	var buf bytes.Buffer
+	printNames(&buf, matched, false, false)
+	if buf.String() != "web-1\nweb 2\n" {
+		t.Fatalf("names: got %q", buf.String())
+	}
+	buf.Reset()
+	printNames(&buf, matched, true, true)
+	if buf.String() != "ns1/web-1\x00ns2/web 2\x00" {
+		t.Fatalf("-A --print0: got %q", buf.String())
+	}
+
+	fr := &fakeRunner{outputs: map[string]string{"get pods -o json": discoveryJSON("web-1", "db")}, errs: map[string]error{}}
+	opts := CLIOptions{Verb: VerbGet, Resource: "pods", Include: []string{"web-*"}, Mode: MatchGlob, NamesOnly: true}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range fr.calls {
+		if c[0] == "get" && strings.Join(c, " ") != "get pods -o json" {
+			t.Fatalf("--names-only must not run the final kubectl get; calls=%v", fr.calls)
+		}
+	}
+	if _, err := parseArgs([]string{"delete", "pods", "*", "-q"}); err == nil {
+		t.Fatalf("--names-only with delete must error")
+	}
+}
+
+func TestCondition_DeploymentAvailableFalse(t *testing.T) {
+	list := `{"items":[
+		{"metadata":{"name":"web","namespace":"prod"},"status":{"conditions":[
+			{"type":"Available","status":"False","reason":"MinimumReplicasUnavailable"},
+			{"type":"Progressing","status":"True"}]}},
+		{"metadata":{"name":"api","namespace":"prod"},"status":{"conditions":[
+			{"type":"Available","status":"True"},{"type":"Progressing","status":"True"}]}},
+		{"metadata":{"name":"new","namespace":"prod"}}]}`
+	fr := &fakeRunner{outputs: map[string]string{"get deployments -o json": list}, errs: map[string]error{}}
+	opts, err := parseArgs([]string{"get", "deployments", "*", "--condition", "Available=false", "--condition", "Progressing=True"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matched) != 1 || matched[0].name != "web" {
+		t.Fatalf("expected only web (Available=False), got %+v", matched)
+	}
+	if _, err := parseArgs([]string{"get", "deploy", "--condition", "Available=Maybe"}); err == nil {
+		t.Fatalf("expected error for an invalid condition status")
+	}
+}
+
+func TestErrorOnEmpty(t *testing.T) {
+	list := `{"items":[{"metadata":{"name":"web-1","namespace":"ns"},"status":{"phase":"Running"}}]}`
+	run := func(opts CLIOptions) error {
+		fr := &fakeRunner{outputs: map[string]string{"get pods -o json -A": list}, errs: map[string]error{}}
+		opts.Verb, opts.Resource, opts.Mode, opts.AllNamespaces = VerbGet, "pods", MatchGlob, true
+		opts.DiscoveryFlags = []string{"-A"}
+		return runCommand(fr, opts)
+	}
+	if err := run(CLIOptions{Include: []string{"api-*"}}); err != nil {
+		t.Fatalf("without --error-on-empty an empty match must succeed, got %v", err)
+	}
+	if err := run(CLIOptions{Include: []string{"api-*"}, ErrorOnEmpty: true}); !errors.Is(err, errNoMatches) {
+		t.Fatalf("expected errNoMatches, got %v", err)
+	}
+	if err := run(CLIOptions{Include: []string{"api-*"}, ErrorOnEmpty: true, NamesOnly: true}); !errors.Is(err, errNoMatches) {
+		t.Fatalf("expected errNoMatches with --names-only, got %v", err)
+	}
+	if err := run(CLIOptions{Include: []string{"web-*"}, ErrorOnEmpty: true, NamesOnly: true}); err != nil {
+		t.Fatalf("a non-empty match must succeed, got %v", err)
+	}
+	if exitCodeFor(errNoMatches) != 1 {
+		t.Fatalf("errNoMatches must map to exit 1")
+	}
+}
+
+func TestSample_SeededPickIsDeterministic(t *testing.T) {
+	list := discoveryJSON("web-1", "web-2", "web-3", "web-4", "web-5")
+	pick := func() []string {
+		fr := &fakeRunner{outputs: map[string]string{"get pods -o json": list}, errs: map[string]error{}}
+		opts, err := parseArgs([]string{"describe", "pods", "web-*", "--sample", "2", "--seed", "1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		matched, err := discoverMatched(fr, &opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, m := range matched {
+			names = append(names, m.name)
+		}
+		return names
+	}
+	first := pick()
+	if len(first) != 2 || first[0] == first[1] {
+		t.Fatalf("expected two distinct samples, got %v", first)
+	}
+	for i := 0; i < 3; i++ {
+		if again := pick(); !reflect.DeepEqual(again, first) {
+			t.Fatalf("--seed 1 must pick the same items: %v vs %v", first, again)
+		}
+	}
+	if got := sampleMatched([]matchedRef{{name: "a"}}, 2, 1); len(got) != 1 {
+		t.Fatalf("sampling more than available must keep all, got %v", got)
+	}
+	if _, err := parseArgs([]string{"get", "pods", "--seed", "1"}); err == nil {
+		t.Fatalf("--seed without --sample must error")
+	}
+}
+
+func TestInvert_KeepsNonMatching(t *testing.T) {
+	names := func(opts CLIOptions) string {
+		list := `{"items":[
+			{"metadata":{"name":"web-1","namespace":"prod"}},
+			{"metadata":{"name":"api-1","namespace":"prod"}},
+			{"metadata":{"name":"web-canary","namespace":"dev"}}]}`
+		fr := &fakeRunner{outputs: map[string]string{"get pods -o json": list, "get pods -o json -A": list}, errs: map[string]error{}}
+		opts.Verb, opts.Resource, opts.Invert = VerbGet, "pods", true
+		if opts.AllNamespaces {
+			opts.DiscoveryFlags = []string{"-A"}
+		}
+		matched, err := discoverMatched(fr, &opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out []string
+		for _, m := range matched {
+			out = append(out, m.name)
+		}
+		return strings.Join(out, ",")
+	}
+	if got := names(CLIOptions{Include: []string{"api-*"}, Mode: MatchGlob}); got != "web-1,web-canary" {
+		t.Fatalf("--invert api-*: got %q", got)
+	}
+	if got := names(CLIOptions{Include: []string{"api-*"}, Exclude: []string{"*canary"}, Mode: MatchGlob}); got != "web-1" {
+		t.Fatalf("--invert must still honor --exclude: got %q", got)
+	}
+	if got := names(CLIOptions{Include: []string{"^api"}, Mode: MatchRegex}); got != "web-1,web-canary" {
+		t.Fatalf("--invert --regex: got %q", got)
+	}
+	if got := names(CLIOptions{Include: []string{"prod/*"}, Mode: MatchGlob, AllNamespaces: true}); got != "web-canary" {
+		t.Fatalf("--invert -A must consider namespace/name: got %q", got)
+	}
+}
+
+func TestAffinityFilters(t *testing.T) {
+	list := "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"pinned\",\"namespace\":\"ns\"},\"spec\":{\"affinity\":{\"nodeAffinity\":{\"requiredDuringSchedulingIgnoredDuringExecution\":{\"nodeSelectorTerms\":[{\"matchExpressions\":[{\"key\":\"zone\",\"operator\":\"In\",\"values\":[\"a\"]}]}]}}}}}," +
+		"{\"metadata\":{\"name\":\"plain\",\"namespace\":\"ns\"},\"spec\":{}}]}"
+	if joined := matchedNames(t, list, "get", "pods", "*", "--has-affinity"); joined != "pinned" {
+		t.Fatalf("--has-affinity mismatch: %s", joined)
+	}
+	if joined := matchedNames(t, list, "get", "pods", "*", "--no-affinity"); joined != "plain" {
+		t.Fatalf("--no-affinity mismatch: %s", joined)
+	}
+	if _, err := parseArgs([]string{"get", "pods", "*", "--has-affinity", "--no-affinity"}); err == nil {
+		t.Fatalf("expected error combining --has-affinity and --no-affinity")
+	}
+}
+
+func TestCommaSeparatedNsAndNode(t *testing.T) {
+	opts, err := parseArgs([]string{"get", "pods", "*", "-A", "--ns", "a, ,b", "--ns", "c,d,e", "--ns-prefix", "prod-,stg-", "--node", "n1,n2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(opts.NsExact, []string{"a", "b", "c", "d", "e"}) {
+		t.Fatalf("NsExact=%v", opts.NsExact)
+	}
+	if !reflect.DeepEqual(opts.NsPrefix, []string{"prod-", "stg-"}) {
+		t.Fatalf("NsPrefix=%v", opts.NsPrefix)
+	}
+	if !reflect.DeepEqual(opts.NodeExact, []string{"n1", "n2"}) {
+		t.Fatalf("NodeExact=%v", opts.NodeExact)
+	}
+
+	// Five exact namespaces from one value take the map fast path
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -A"] = "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"x\",\"namespace\":\"e\"}}," +
+		"{\"metadata\":{\"name\":\"y\",\"namespace\":\"f\"}}]}"
+	opts, err = parseArgs([]string{"get", "pods", "*", "-A", "--ns", "a,b,c,d,e"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matched) != 1 || matched[0].name != "x" {
+		t.Fatalf("expected only e/x, got %+v", matched)
+	}
+}
+
+func TestClientTable_TwoPods(t *testing.T) {
+	origNow := now
+	defer func() { now = origNow }()
+	now = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -A"] = "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"web-1\",\"namespace\":\"prod\",\"creationTimestamp\":\"2025-06-01T09:00:00Z\"},\"spec\":{\"nodeName\":\"node-a\"}," +
+		"\"status\":{\"phase\":\"Running\",\"podIP\":\"10.0.0.7\",\"containerStatuses\":[" +
+		"{\"name\":\"app\",\"ready\":true,\"restartCount\":1,\"state\":{\"running\":{}}}," +
+		"{\"name\":\"sidecar\",\"ready\":false,\"restartCount\":4,\"state\":{\"waiting\":{\"reason\":\"CrashLoopBackOff\"}}}]}}," +
+		"{\"metadata\":{\"name\":\"web-2\",\"namespace\":\"dev\",\"creationTimestamp\":\"2025-05-30T12:00:00Z\"},\"spec\":{}," +
+		"\"status\":{\"phase\":\"Pending\",\"containerStatuses\":[{\"name\":\"app\",\"ready\":false,\"state\":{\"waiting\":{\"reason\":\"ContainerCreating\"}}}]}}," +
+		"{\"metadata\":{\"name\":\"db-1\",\"namespace\":\"prod\"}}]}"
+	opts, err := parseArgs([]string{"get", "pods", "web-*", "-A", "--client-table"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := printClientTable(&buf, matched, "", false, now(), true); err != nil {
+		t.Fatal(err)
+	}
+	want := "" +
+		"NAMESPACE   NAME    READY   STATUS              RESTARTS   AGE   IP         NODE\n" +
+		"dev         web-2   0/1     ContainerCreating   0          2d    <none>     <none>\n" +
+		"prod        web-1   1/2     CrashLoopBackOff    5          3h    10.0.0.7   node-a\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected client table:\n%s\nwant:\n%s", buf.String(), want)
+	}
+	for _, c := range fr.calls {
+		if len(c) > 1 && c[0] == "get" && c[1] == "-f" {
+			t.Fatalf("client table must not call kubectl again: %v", c)
+		}
+	}
+
+	if _, err := parseArgs([]string{"get", "pods", "*", "--client-table"}); err == nil {
+		t.Fatalf("expected --client-table without -A to fail")
+	}
+	if _, err := parseArgs([]string{"get", "deployments", "*", "-A", "--client-table"}); err == nil {
+		t.Fatalf("expected --client-table for non-pods to fail")
+	}
+}
+
+func TestHumanAge(t *testing.T) {
+	cases := map[time.Duration]string{
+		45 * time.Second:                  "45s",
+		5*time.Minute + 30*time.Second:    "5m30s",
+		90 * time.Minute:                  "90m",
+		5*time.Hour + 10*time.Minute:      "5h10m",
+		30 * time.Hour:                    "30h",
+		3*24*time.Hour + 4*time.Hour:      "3d4h",
+		40 * 24 * time.Hour:               "40d",
+		3*365*24*time.Hour + 24*time.Hour: "3y1d",
+	}
+	for d, want := range cases {
+		if got := humanAge(d); got != want {
+			t.Errorf("humanAge(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestNsExcludePrefix_DropsKubeSystem(t *testing.T) {
+	list := "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"coredns\",\"namespace\":\"kube-system\"}}," +
+		"{\"metadata\":{\"name\":\"api\",\"namespace\":\"prod\"}}," +
+		"{\"metadata\":{\"name\":\"gw\",\"namespace\":\"istio-system\"}}]}"
+	if opts, err := parseArgs([]string{"get", "pods", "*", "--ns-exclude", "kube-system"}); err != nil || !opts.AllNamespaces {
+		t.Fatalf("expected namespace excludes to force -A, got %v", err)
+	}
+	if got := matchedKeys(t, list, "get", "pods", "*", "--ns-exclude-prefix", "kube-"); got != "prod/api,istio-system/gw" {
+		t.Fatalf("--ns-exclude-prefix kube-: got %q", got)
+	}
+	if got := matchedKeys(t, list, "get", "pods", "*", "--ns-exclude-prefix", "kube-,istio-"); got != "prod/api" {
+		t.Fatalf("--ns-exclude-prefix kube-,istio-: got %q", got)
+	}
+	// Excludes win over a matching include
+	if got := matchedKeys(t, list, "get", "pods", "*", "--ns-prefix", "kube-,prod", "--ns-exclude", "kube-system"); got != "prod/api" {
+		t.Fatalf("--ns-exclude over --ns-prefix: got %q", got)
+	}
+}
+
+func TestMatcher_NamespaceAllowed_ExcludeWins(t *testing.T) {
+	m := Matcher{NsExact: []string{"kube-system"}, NsExclude: []string{"kube-system"}}
+	if m.NamespaceAllowed("kube-system") {
+		t.Fatal("exclude should take precedence over include")
+	}
+	m = Matcher{NsExcludePrefix: []string{"istio-"}}
+	if m.NamespaceAllowed("istio-system") || !m.NamespaceAllowed("prod") {
+		t.Fatal("NsExcludePrefix failed")
+	}
+}
+
+func TestOrdinalRange_StatefulSetPods(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	var items []string
+	for i := 0; i < 5; i++ {
+		items = append(items, fmt.Sprintf("{\"metadata\":{\"name\":\"db-%d\",\"namespace\":\"ns\",\"ownerReferences\":[{\"kind\":\"StatefulSet\",\"name\":\"db\"}]}}", i))
+	}
+	// A Deployment pod with a numeric-looking suffix is not an ordinal
+	items = append(items, "{\"metadata\":{\"name\":\"web-2\",\"namespace\":\"ns\",\"ownerReferences\":[{\"kind\":\"ReplicaSet\",\"name\":\"web-abc\"}]}}")
+	// Unowned pods fall back to the trailing number
+	items = append(items, "{\"metadata\":{\"name\":\"standalone-3\",\"namespace\":\"ns\"}}")
+	fr.outputs["get pods -o json"] = "{\"items\":[" + strings.Join(items, ",") + "]}"
+	opts, err := parseArgs([]string{"get", "pods", "*", "--ordinal-range", "1-3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, m := range matched {
+		names = append(names, m.name)
+	}
+	if got := strings.Join(names, ","); got != "db-1,db-2,db-3,standalone-3" {
+		t.Fatalf("unexpected ordinals: %s", got)
+	}
+	for _, bad := range []string{"3-1", "a-b", "-2", "1-"} {
+		if _, err := parseArgs([]string{"get", "pods", "*", "--ordinal-range", bad}); err == nil {
+			t.Fatalf("expected --ordinal-range %q to fail", bad)
+		}
+	}
+}
+
+func TestGlobMatch_CrossesSlash(t *testing.T) {
+	m := Matcher{Mode: MatchGlob, Includes: []string{"*"}}
+	if !m.Matches("ns1/pod-a") {
+		t.Fatal("'*' should match ns1/pod-a")
+	}
+	m = Matcher{Mode: MatchGlob, Includes: []string{"prod/*"}}
+	if !m.Matches("prod/web") || m.Matches("dev/web") {
+		t.Fatal("'prod/*' should match prod/web but not dev/web")
+	}
+	m = Matcher{Mode: MatchGlob, Includes: []string{"prod-?/api-*"}}
+	if !m.Matches("prod-a/api-1") || m.Matches("prod-a/web-1") {
+		t.Fatal("'prod-?/api-*' mismatch")
+	}
+
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -A"] = "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"api-1\",\"namespace\":\"prod-eu\"}}," +
+		"{\"metadata\":{\"name\":\"api-2\",\"namespace\":\"dev\"}}," +
+		"{\"metadata\":{\"name\":\"web-1\",\"namespace\":\"prod-us\"}}]}"
+	opts, err := parseArgs([]string{"get", "pods", "prod-*/api-*", "-A"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matched) != 1 || matched[0].ns != "prod-eu" || matched[0].name != "api-1" {
+		t.Fatalf("expected only prod-eu/api-1, got %+v", matched)
+	}
+}
+
+func TestRequireNamespace_DropsItemWithoutNamespace(t *testing.T) {
+	list := "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"web-1\",\"namespace\":\"prod\"}}," +
+		"{\"metadata\":{\"name\":\"web-2\"}}]}"
+	if got := matchedKeys(t, list, "get", "pods", "web-*", "-A"); got != "prod/web-1,/web-2" {
+		t.Fatalf("without --require-namespace: got %q", got)
+	}
+	if got := matchedKeys(t, list, "get", "pods", "web-*", "-A", "--require-namespace"); got != "prod/web-1" {
+		t.Fatalf("with --require-namespace: got %q", got)
+	}
+}
+
+func TestDeleteAllNamespaces_EmptyNamespaceNotPassedAsFlag(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -A"] = "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"web-1\",\"namespace\":\"prod\"}}," +
+		"{\"metadata\":{\"name\":\"web-2\"}}]}"
+	opts, err := parseArgs([]string{"delete", "pods", "web-*", "-A", "-y"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range fr.calls {
+		for i := 0; i+1 < len(c); i++ {
+			if c[i] == "-n" && c[i+1] == "" {
+				t.Fatalf("empty namespace passed as -n \"\": %v", c)
+			}
+		}
+	}
+}
+
+func TestFuzzy_UnicodeNames(t *testing.T) {
+	// "é" is two bytes; by bytes this would be two edits away
+	if !fuzzyContains("café-api-7d9f", "cafe-api", 1, false) {
+		t.Fatal("expected café-api to be one edit from cafe-api")
+	}
+	if !fuzzyContains("ÜBER-svc", "über-svc", 0, true) {
+		t.Fatal("expected ignore-case fuzzy to fold non-ASCII uppercase")
+	}
+	if got := levenshteinBounded([]rune("größe"), []rune("grösse"), 3); got != 2 {
+		t.Fatalf("expected rune distance 2, got %d", got)
+	}
+
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json"] = discoveryJSON("café-api-7d9f", "web-1")
+	opts, err := parseArgs([]string{"get", "pods", "cafe-api", "--fuzzy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matched) != 1 || matched[0].name != "café-api-7d9f" {
+		t.Fatalf("expected café-api-7d9f, got %+v", matched)
+	}
+}
+
+func TestDisplayResource(t *testing.T) {
+	cases := map[string]string{
+		"po":                  "pods",
+		"Pod":                 "pods",
+		"svc":                 "services",
+		"deploy":              "deployments",
+		"deployments.apps":    "deployments",
+		"bgppeers.metallb.io": "bgppeers",
+		"widgets":             "widgets",
+	}
+	for in, want := range cases {
+		if got := displayResource(in); got != want {
+			t.Errorf("displayResource(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNoMatchesMessage_UsesDisplayName(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get po -o json"] = discoveryJSON("web-1")
+	opts, err := parseArgs([]string{"get", "po", "api-*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	origStderr := os.Stderr
+	os.Stderr = w
+	runErr := runCommand(fr, opts)
+	os.Stderr = origStderr
+	w.Close()
+	out, _ := io.ReadAll(r)
+	if runErr != nil {
+		t.Fatal(runErr)
+	}
+	if !strings.Contains(string(out), "No pods matched") || strings.Contains(string(out), "No po matched") {
+		t.Fatalf("unexpected message: %q", out)
+	}
+	// kubectl still gets the resource as typed
+	if opts.Resource != "po" {
+		t.Fatalf("resource for kubectl changed to %q", opts.Resource)
+	}
+}
+
+func TestNativeSelector_ConfirmThresholdCountsFilteredSet(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	// The server applied app=web; only web-prod also passes --label env=prod-*
+	fr.outputs["get pods -o json -l app=web"] = "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"web-prod\",\"namespace\":\"ns\",\"labels\":{\"app\":\"web\",\"env\":\"prod-eu\"}}}," +
+		"{\"metadata\":{\"name\":\"web-dev\",\"namespace\":\"ns\",\"labels\":{\"app\":\"web\",\"env\":\"dev\"}}}]}"
+	opts, err := parseArgs([]string{"delete", "pods", "-l", "app=web", "--label", "env=prod-*", "--confirm-threshold", "1", "--dry-run"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matched) != 1 || matched[0].name != "web-prod" {
+		t.Fatalf("expected -l and --label to AND down to web-prod, got %+v", matched)
+	}
+	// One match is within the threshold, so the dry run proceeds instead of aborting
+	fr.calls = nil
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	origStdout := os.Stdout
+	os.Stdout = w
+	runErr := runCommand(fr, opts)
+	os.Stdout = origStdout
+	w.Close()
+	out, _ := io.ReadAll(r)
+	if runErr != nil {
+		t.Fatal(runErr)
+	}
+	if !strings.Contains(string(out), "Would delete 1 pods: web-prod") {
+		t.Fatalf("expected dry run of the filtered set, got %q", out)
+	}
+}
+
+func TestFieldSelector_DiscoveryOnly(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -n ns --field-selector status.phase=Running"] = discoveryJSON("web-1", "api-1")
+	opts, err := parseArgs([]string{"delete", "pods", "web-*", "-n", "ns", "--field-selector", "status.phase=Running", "-y"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(opts.Include, []string{"web-*"}) {
+		t.Fatalf("selector value must not become a name pattern, got %v", opts.Include)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(fr.calls) == 0 || strings.Join(fr.calls[0], " ") != "get pods -o json -n ns --field-selector status.phase=Running" {
+		t.Fatalf("expected discovery with the field selector; calls=%v", fr.calls)
+	}
+	joined := finalArgs(fr, "delete", "pods")
+	if !strings.Contains(joined, " web-1") || strings.Contains(joined, "api-1") {
+		t.Fatalf("expected delete of web-1 only; calls=%v", fr.calls)
+	}
+	if strings.Contains(joined, "--field-selector") {
+		t.Fatalf("field selector must not reach the final delete; calls=%v", fr.calls)
+	}
+}
+
+func TestEvents_OnlyForMatchedObjects(t *testing.T) {
+	origNow := now
+	defer func() { now = origNow }()
+	now = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -A"] = "{\"items\":[" +
+		"{\"kind\":\"Pod\",\"metadata\":{\"name\":\"web-1\",\"namespace\":\"prod\"}}," +
+		"{\"kind\":\"Pod\",\"metadata\":{\"name\":\"api-1\",\"namespace\":\"prod\"}}]}"
+	fr.outputs["get events -A -o json"] = "{\"items\":[" +
+		"{\"involvedObject\":{\"kind\":\"Pod\",\"namespace\":\"prod\",\"name\":\"web-1\"},\"type\":\"Warning\",\"reason\":\"BackOff\",\"message\":\"Back-off restarting failed container\",\"lastTimestamp\":\"2025-06-01T11:58:00Z\"}," +
+		"{\"involvedObject\":{\"kind\":\"Pod\",\"namespace\":\"prod\",\"name\":\"api-1\"},\"type\":\"Normal\",\"reason\":\"Pulled\",\"message\":\"pulled\",\"lastTimestamp\":\"2025-06-01T11:59:00Z\"}," +
+		"{\"involvedObject\":{\"kind\":\"ReplicaSet\",\"namespace\":\"prod\",\"name\":\"web-1\"},\"type\":\"Normal\",\"reason\":\"SuccessfulCreate\",\"message\":\"same name, other kind\",\"lastTimestamp\":\"2025-06-01T11:00:00Z\"}," +
+		"{\"involvedObject\":{\"kind\":\"Pod\",\"namespace\":\"prod\",\"name\":\"web-1\"},\"type\":\"Normal\",\"reason\":\"Scheduled\",\"message\":\"Successfully assigned prod/web-1\",\"eventTime\":\"2025-06-01T11:00:00Z\"}]}"
+	opts, err := parseArgs([]string{"get", "pods", "web-*", "-A", "--events"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := runEvents(&buf, fr, opts, matched); err != nil {
+		t.Fatal(err)
+	}
+	want := "" +
+		"NAMESPACE   LAST SEEN   TYPE      REASON      OBJECT      MESSAGE\n" +
+		"prod        60m         Normal    Scheduled   pod/web-1   Successfully assigned prod/web-1\n" +
+		"prod        2m          Warning   BackOff     pod/web-1   Back-off restarting failed container\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected events table:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	if _, err := parseArgs([]string{"delete", "pods", "*", "--events"}); err == nil {
+		t.Fatalf("expected --events with delete to fail")
+	}
+}
+
+func TestScale_BatchedWithReplicas(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get deploy -o json -n jobs"] = discoveryJSON("batch-a", "batch-b", "web")
+	opts, err := parseArgs([]string{"scale", "deploy", "batch-*", "--replicas", "0", "-n", "jobs", "-y"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	var scaleCalls []string
+	for _, c := range fr.calls {
+		if len(c) > 0 && c[0] == "scale" {
+			scaleCalls = append(scaleCalls, strings.Join(c, " "))
+		}
+	}
+	if len(scaleCalls) != 1 || scaleCalls[0] != "scale deploy batch-a batch-b -n jobs --replicas=0" {
+		t.Fatalf("expected one batched scale call, got %v", scaleCalls)
+	}
+
+	// Over the confirm threshold nothing is scaled without -y
+	fr.calls = nil
+	opts, err = parseArgs([]string{"scale", "deploy", "batch-*", "--replicas", "0", "-n", "jobs", "--confirm-threshold", "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	// Dry run only reports
+	opts, err = parseArgs([]string{"scale", "deploy", "batch-*", "--replicas", "0", "-n", "jobs", "--dry-run"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range fr.calls {
+		if len(c) > 0 && c[0] == "scale" {
+			t.Fatalf("unexpected scale call: %v", c)
+		}
+	}
+
+	if _, err := parseArgs([]string{"scale", "deploy", "batch-*"}); err == nil {
+		t.Fatalf("expected scale without --replicas to fail")
+	}
+	if _, err := parseArgs([]string{"get", "deploy", "batch-*", "--replicas", "1"}); err == nil {
+		t.Fatalf("expected --replicas with get to fail")
+	}
+}
+
+func TestClientTable_SortByRestartsDescending(t *testing.T) {
+	origNow := now
+	defer func() { now = origNow }()
+	now = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+	pod := func(ns, name string, restarts int) string {
+		return fmt.Sprintf("{\"metadata\":{\"name\":%q,\"namespace\":%q},\"status\":{\"phase\":\"Running\","+
+			"\"containerStatuses\":[{\"name\":\"app\",\"ready\":true,\"restartCount\":%d,\"state\":{\"running\":{}}}]}}", name, ns, restarts)
+	}
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -A"] = "{\"items\":[" + pod("a", "web-1", 2) + "," + pod("b", "web-2", 9) + "," + pod("c", "web-3", 0) + "]}"
+	opts, err := parseArgs([]string{"get", "pods", "web-*", "-A", "--client-table", "--sort-by", "restarts", "--sort-reverse"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.SortBy != "restarts" || containsFlag(opts.FinalFlags, "--sort-by") || !reflect.DeepEqual(opts.Include, []string{"web-*"}) {
+		t.Fatalf("unexpected parse: SortBy=%q FinalFlags=%v Include=%v", opts.SortBy, opts.FinalFlags, opts.Include)
+	}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := printClientTable(&buf, matched, opts.SortBy, opts.SortReverse, now(), false); err != nil {
+		t.Fatal(err)
+	}
+	var order []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		f := strings.Fields(line)
+		order = append(order, f[1]+":"+f[4])
+	}
+	if got := strings.Join(order, ","); got != "web-2:9,web-1:2,web-3:0" {
+		t.Fatalf("expected rows by descending restarts, got %s", got)
+	}
+
+	// Any other value is kubectl's JSONPath and is forwarded as-is
+	opts, err = parseArgs([]string{"get", "pods", "web-*", "--sort-by", ".metadata.name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.SortBy != "" || !containsFlag(opts.FinalFlags, ".metadata.name") || !reflect.DeepEqual(opts.Include, []string{"web-*"}) {
+		t.Fatalf("expected JSONPath forwarded to kubectl, got SortBy=%q FinalFlags=%v Include=%v", opts.SortBy, opts.FinalFlags, opts.Include)
+	}
+	if _, err := parseArgs([]string{"get", "pods", "*", "-A", "--sort-reverse"}); err == nil {
+		t.Fatalf("expected --sort-reverse without --sort-by to fail")
+	}
+}
+
+func TestRolloutRestart_OnePerNamePerNamespace(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get deployments -o json -A"] = `{"items":[` +
+		`{"metadata":{"name":"api-b","namespace":"prod"}},` +
+		`{"metadata":{"name":"api-a","namespace":"prod"}},` +
+		`{"metadata":{"name":"api-a","namespace":"stage"}},` +
+		`{"metadata":{"name":"web","namespace":"prod"}}]}`
+	fr.outputs["api-resources -o name --verbs=list --namespaced=true"] = "deployments.apps\n"
+	opts, err := parseArgs([]string{"rollout-restart", "deployments", "api-*", "-A"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	var restarts []string
+	for _, c := range fr.calls {
+		if len(c) > 0 && c[0] == "rollout" {
+			restarts = append(restarts, strings.Join(c, " "))
+		}
+	}
+	want := []string{
+		"rollout restart deployments api-a -n prod",
+		"rollout restart deployments api-b -n prod",
+		"rollout restart deployments api-a -n stage",
+	}
+	if !reflect.DeepEqual(restarts, want) {
+		t.Fatalf("expected one rollout restart per name, got %v", restarts)
+	}
+
+	// Dry run only reports
+	fr.calls = nil
+	opts, err = parseArgs([]string{"rollout-restart", "deployments", "api-*", "-A", "--dry-run"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range fr.calls {
+		if len(c) > 0 && c[0] == "rollout" {
+			t.Fatalf("unexpected rollout call: %v", c)
+		}
+	}
+
+	if _, err := parseArgs([]string{"rollout-restart", "pods", "api-*"}); err == nil {
+		t.Fatalf("expected rollout-restart to reject pods")
+	}
+}
+
+// kubeconfigRecorder records KUBECONFIG as seen by each kubectl invocation.
+type kubeconfigRecorder struct {
+	*fakeRunner
+	envs []string
+}
+
+func (r *kubeconfigRecorder) CaptureKubectl(args []string) ([]byte, []byte, error) {
+	r.envs = append(r.envs, os.Getenv("KUBECONFIG")+": "+strings.Join(args, " "))
+	if os.Getenv("KUBECONFIG") == "b.yaml" && args[0] == "get" {
+		return nil, nil, errors.New("connection refused")
+	}
+	return r.fakeRunner.CaptureKubectl(args)
+}
+
+func (r *kubeconfigRecorder) RunKubectl(args []string) error {
+	r.envs = append(r.envs, os.Getenv("KUBECONFIG")+": "+strings.Join(args, " "))
+	return r.fakeRunner.RunKubectl(args)
+}
+
+func TestKubeconfigs_DiscoveryPerFile(t *testing.T) {
+	t.Setenv("KUBECONFIG", "orig.yaml")
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -n prod"] = discoveryJSON("api-1", "web-1")
+	fr.outputs["config current-context"] = "prod-eu\n"
+	rec := &kubeconfigRecorder{fakeRunner: fr}
+	opts, err := parseArgs([]string{"get", "pods", "api-*", "-n", "prod", "--kubeconfigs", "a.yaml,b.yaml,c.yaml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(opts.Kubeconfigs, []string{"a.yaml", "b.yaml", "c.yaml"}) {
+		t.Fatalf("unexpected Kubeconfigs: %v", opts.Kubeconfigs)
+	}
+
+	r, w, _ := os.Pipe()
+	origStderr := os.Stderr
+	os.Stderr = w
+	err = runCommand(rec, opts)
+	w.Close()
+	os.Stderr = origStderr
+	out, _ := io.ReadAll(r)
+
+	// b.yaml fails, but c.yaml is still processed and the failure is reported at the end
+	if err == nil || !strings.Contains(err.Error(), "b.yaml: ") {
+		t.Fatalf("expected the b.yaml failure to be returned, got %v", err)
+	}
+	for _, want := range []string{
+		"a.yaml: get pods -o json -n prod",
+		"a.yaml: get pods api-1 -n prod",
+		"b.yaml: get pods -o json -n prod",
+		"c.yaml: get pods -o json -n prod",
+		"c.yaml: get pods api-1 -n prod",
+	} {
+		found := false
+		for _, e := range rec.envs {
+			if e == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("missing call %q in %v", want, rec.envs)
+		}
+	}
+	if !strings.Contains(string(out), "==> a.yaml (prod-eu) <==") || !strings.Contains(string(out), "==> c.yaml (prod-eu) <==") {
+		t.Fatalf("expected per-file headers on stderr, got %q", out)
+	}
+	if got := os.Getenv("KUBECONFIG"); got != "orig.yaml" {
+		t.Fatalf("expected KUBECONFIG restored, got %q", got)
+	}
+
+	if _, err := parseArgs([]string{"get", "pods", "*", "--kubeconfigs", "a.yaml", "--kubeconfig", "b.yaml"}); err == nil {
+		t.Fatalf("expected --kubeconfigs with --kubeconfig to fail")
+	}
+}
+
+func TestLabelAndAnnotate_BatchedWithMutation(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -n web"] = discoveryJSON("canary-a", "canary-b", "stable-a")
+	opts, err := parseArgs([]string{"label", "pods", "canary-*", "tier=canary", "--overwrite", "-n", "web", "-y"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(opts.Include, []string{"canary-*"}) || !reflect.DeepEqual(opts.Mutations, []string{"tier=canary"}) {
+		t.Fatalf("unexpected parse: Include=%v Mutations=%v", opts.Include, opts.Mutations)
+	}
+	if containsFlag(opts.DiscoveryFlags, "--overwrite") {
+		t.Fatalf("--overwrite must not reach discovery: %v", opts.DiscoveryFlags)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got := finalArgs(fr, "label", "pods"); got != " canary-a canary-b -n web --overwrite tier=canary " {
+		t.Fatalf("expected one batched label call with both names and the label, got %q", got)
+	}
+
+	// Removal form, and the mutation may come right after the resource
+	fr.calls = nil
+	opts, err = parseArgs([]string{"annotate", "pods", "note-", "--match", "canary-*", "-n", "web", "-y"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got := finalArgs(fr, "annotate", "pods"); got != " canary-a canary-b -n web note- " {
+		t.Fatalf("expected annotation removal for both canaries, got %q", got)
+	}
+
+	if _, err := parseArgs([]string{"label", "pods", "canary-*"}); err == nil {
+		t.Fatalf("expected label without key=value to fail")
+	}
+}
+
+func TestPatch_PerNameAndKeepsGoingOnFailure(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get deploy -o json -A"] = `{"items":[` +
+		`{"metadata":{"name":"web-a","namespace":"prod"}},` +
+		`{"metadata":{"name":"web-b","namespace":"prod"}},` +
+		`{"metadata":{"name":"web-a","namespace":"stage"}}]}`
+	fr.outputs["api-resources -o name --verbs=list --namespaced=true"] = "deployments.apps\ndeploy\n"
+	body := `{"spec":{"paused":true}}`
+	fr.errs["patch deploy web-a -n prod --type=merge -p "+body] = errors.New("conflict")
+	opts, err := parseArgs([]string{"patch", "deploy", "web-*", "-A", "-y", "--", "--type=merge", "-p", body})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = runCommand(fr, opts)
+	if err == nil || err.Error() != "conflict" {
+		t.Fatalf("expected the failed patch to be reported at the end, got %v", err)
+	}
+	var patches []string
+	for _, c := range fr.calls {
+		if len(c) > 0 && c[0] == "patch" {
+			patches = append(patches, strings.Join(c, " "))
+		}
+	}
+	want := []string{
+		"patch deploy web-a -n prod --type=merge -p " + body,
+		"patch deploy web-b -n prod --type=merge -p " + body,
+		"patch deploy web-a -n stage --type=merge -p " + body,
+	}
+	if !reflect.DeepEqual(patches, want) {
+		t.Fatalf("expected one patch per name across namespaces, got %v", patches)
+	}
+
+	// Server-side dry run is forwarded
+	fr.calls = nil
+	opts, err = parseArgs([]string{"patch", "deploy", "web-b", "-n", "prod", "-y", "--server-dry-run", "--", "-p", body})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fr.outputs["get deploy -o json -n prod"] = discoveryJSON("web-a", "web-b")
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got := finalArgs(fr, "patch", "deploy"); got != " web-b -n prod --dry-run=server -p "+body+" " {
+		t.Fatalf("expected server dry-run patch, got %q", got)
+	}
+
+	if _, err := parseArgs([]string{"patch", "deploy", "web-*"}); err == nil {
+		t.Fatalf("expected patch without a patch body to fail")
+	}
+}
+
+func TestSuggest_NearMissPattern(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -n web"] = discoveryJSON("nginx", "redis", "postgres")
+	if out := runStderr(t, fr, "get", "pods", "ngnx", "-n", "web"); !strings.Contains(out, "No pods matched 'ngnx'. Did you mean 'nginx'?") {
+		t.Fatalf("expected a suggestion, got %q", out)
+	}
+	// The hint reuses the discovered list instead of listing again
+	lists := 0
+	for _, c := range fr.calls {
+		if strings.Join(c, " ") == "get pods -o json -n web" {
+			lists++
+		}
+	}
+	if lists != 1 {
+		t.Fatalf("expected a single list call, got %d: %v", lists, fr.calls)
+	}
+	// Nothing close enough, wildcards, --no-suggest, and a name that matched but
+	// was filtered out (no pod is Failed) keep the plain message
+	for _, args := range [][]string{
+		{"get", "pods", "kafka", "-n", "web"},
+		{"get", "pods", "ngnx-*", "-n", "web"},
+		{"get", "pods", "ngnx", "-n", "web", "--no-suggest"},
+		{"get", "pods", "nginx", "-n", "web", "--status", "Failed"},
+	} {
+		if out := runStderr(t, fr, args...); strings.Contains(out, "Did you mean") || !strings.Contains(out, "No pods matched given criteria.") {
+			t.Fatalf("%v: expected no suggestion, got %q", args, out)
+		}
+	}
+}
+
+func TestWait_OneCallPerNamespace(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -n ci"] = discoveryJSON("job-a", "job-b", "web")
+	opts, err := parseArgs([]string{"wait", "pods", "job-*", "-n", "ci", "--", "--for=condition=Ready", "--timeout=120s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got := finalArgs(fr, "wait", "pods"); got != " job-a job-b -n ci --for=condition=Ready --timeout=120s " {
+		t.Fatalf("expected one wait call for the matched set, got %q", got)
+	}
+
+	// Under -A every namespace is waited on; a failed wait fails the run at the end
+	fr = &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -A"] = `{"items":[` +
+		`{"metadata":{"name":"job-a","namespace":"ci"}},` +
+		`{"metadata":{"name":"job-b","namespace":"nightly"}}]}`
+	fr.outputs["api-resources -o name --verbs=list --namespaced=true"] = "pods\n"
+	fr.errs["wait pods job-a -n ci --for=delete"] = errors.New("timed out waiting for the condition")
+	opts, err = parseArgs([]string{"wait", "pods", "job-*", "-A", "--", "--for=delete"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err == nil {
+		t.Fatalf("expected the failed wait to fail the run")
+	}
+	if got := finalArgs(fr, "wait", "pods"); got != " job-a -n ci --for=delete job-b -n nightly --for=delete " {
+		t.Fatalf("expected a wait per namespace, got %q", got)
+	}
+
+	if _, err := parseArgs([]string{"wait", "pods", "job-*"}); err == nil {
+		t.Fatalf("expected wait without --for to fail")
+	}
+}
+
+func TestStaleReplicaSet_ScaledDownRevisionsOnly(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get rs -o json -n prod"] = `{"items":[` +
+		`{"metadata":{"name":"web-6d4f","namespace":"prod"},"spec":{"replicas":0},"status":{"replicas":0,"observedGeneration":3}},` +
+		`{"metadata":{"name":"web-7b9c","namespace":"prod"},"spec":{"replicas":3},"status":{"replicas":3}},` +
+		`{"metadata":{"name":"web-5a1e","namespace":"prod"},"spec":{"replicas":0},"status":{"replicas":1}},` +
+		`{"metadata":{"name":"web-0000","namespace":"prod"},"spec":{}}]}`
+	opts, err := parseArgs([]string{"get", "rs", "web-*", "-n", "prod", "--stale-replicaset"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	// Still draining (status 1) and defaulted spec.replicas (1) are not stale
+	if got := finalArgs(fr, "get", "rs"); got != " web-6d4f -n prod " {
+		t.Fatalf("expected only the scaled-down revision, got %q", got)
+	}
+	if _, err := parseArgs([]string{"get", "pods", "*", "--stale-replicaset"}); err == nil {
+		t.Fatalf("expected --stale-replicaset on pods to fail")
+	}
+}
+
+func TestContinueOnError_KeepsGoingAndSummarizes(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -n web"] = discoveryJSON("tmp-a", "tmp-b", "tmp-c", "tmp-d", "tmp-e")
+	fr.errs["delete pods tmp-c tmp-d -n web"] = errors.New(`pods "tmp-c" is forbidden`)
+	opts, err := parseArgs([]string{"delete", "pods", "tmp-*", "-n", "web", "-y", "--batch-size", "2", "--continue-on-error"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	origStderr := os.Stderr
+	os.Stderr = w
+	runErr := runCommand(fr, opts)
+	os.Stderr = origStderr
+	w.Close()
+	out, _ := io.ReadAll(r)
+
+	if got := finalArgs(fr, "delete", "pods"); got != " tmp-a tmp-b -n web tmp-c tmp-d -n web tmp-e -n web " {
+		t.Fatalf("expected every batch to run, got %q", got)
+	}
+	if runErr == nil || !strings.Contains(runErr.Error(), "forbidden") {
+		t.Fatalf("expected the batch failure to be returned, got %v", runErr)
+	}
+	if !strings.Contains(string(out), "3 succeeded, 2 failed") {
+		t.Fatalf("expected a summary on stderr, got %q", out)
+	}
+
+	// Without the flag the first failure still stops the run
+	fr.calls = nil
+	opts.ContinueOnError = false
+	if err := runCommand(fr, opts); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got := finalArgs(fr, "delete", "pods"); got != " tmp-a tmp-b -n web tmp-c tmp-d -n web " {
+		t.Fatalf("expected the run to stop at the failed batch, got %q", got)
+	}
+}
+
+// concurrentRunner is a goroutine-safe fake that records calls and the highest
+// number of calls in flight at once.
+type concurrentRunner struct {
+	mu       sync.Mutex
+	calls    [][]string
+	inFlight int
+	maxSeen  int
+	outputs  map[string]string
+	errs     map[string]error
+}
+
+func (c *concurrentRunner) RunKubectl(args []string) error {
+	_, _, err := c.CaptureKubectl(args)
+	return err
+}
+
+func (c *concurrentRunner) CaptureKubectl(args []string) ([]byte, []byte, error) {
+	key := strings.Join(args, " ")
+	c.mu.Lock()
+	c.calls = append(c.calls, append([]string{}, args...))
+	c.inFlight++
+	if c.inFlight > c.maxSeen {
+		c.maxSeen = c.inFlight
+	}
+	c.mu.Unlock()
+	if args[0] == "delete" {
+		time.Sleep(20 * time.Millisecond)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inFlight--
+	if err, ok := c.errs[key]; ok {
+		return nil, []byte(err.Error()), err
+	}
+	return []byte(c.outputs[key]), nil, nil
+}
+
+func TestMaxParallel_DeletesNamespacesConcurrently(t *testing.T) {
+	var items []string
+	for i := 0; i < 6; i++ {
+		items = append(items, fmt.Sprintf(`{"metadata":{"name":"tmp-%d","namespace":"ns-%d"}}`, i, i))
+	}
+	cr := &concurrentRunner{
+		outputs: map[string]string{
+			"get pods -o json -A": `{"items":[` + strings.Join(items, ",") + `]}`,
+			"api-resources -o name --verbs=list --namespaced=true": "pods\n",
+		},
+		errs: map[string]error{
+			"delete pods tmp-4 -n ns-4": errors.New("ns-4 forbidden"),
+			"delete pods tmp-1 -n ns-1": errors.New("ns-1 forbidden"),
+		},
+	}
+	opts, err := parseArgs([]string{"delete", "pods", "tmp-*", "-A", "-y", "--max-parallel", "3", "--continue-on-error"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	origStderr := os.Stderr
+	devNull, _ := os.Open(os.DevNull)
+	os.Stderr = devNull
+	runErr := runCommand(cr, opts)
+	os.Stderr = origStderr
+	devNull.Close()
+
+	deletes := 0
+	for _, c := range cr.calls {
+		if c[0] == "delete" {
+			deletes++
+		}
+	}
+	if deletes != 6 {
+		t.Fatalf("expected a delete per namespace, got %d", deletes)
+	}
+	if cr.maxSeen < 2 || cr.maxSeen > 3 {
+		t.Fatalf("expected 2-3 concurrent deletes, saw %d", cr.maxSeen)
+	}
+	// Errors come back in namespace order, whichever worker failed first
+	if runErr == nil || runErr.Error() != "ns-1 forbidden\nns-4 forbidden" {
+		t.Fatalf("unexpected error: %v", runErr)
+	}
+}
+
+func TestProbeFilters(t *testing.T) {
+	probe := `{"httpGet":{"path":"/healthz","port":8080}}`
+	list := `{"items":[` +
+		`{"metadata":{"name":"probed","namespace":"ns"},"spec":{"containers":[{"name":"app","readinessProbe":` + probe + `,"livenessProbe":` + probe + `}]}},` +
+		`{"metadata":{"name":"no-ready","namespace":"ns"},"spec":{"containers":[{"name":"app","livenessProbe":` + probe + `},{"name":"sidecar","readinessProbe":` + probe + `}]}}]}`
+	// A pod is kept when any container lacks the probe (the sidecar lacks liveness here)
+	if joined := matchedNames(t, list, "get", "pods", "*", "--no-readiness-probe"); joined != "no-ready" {
+		t.Fatalf("--no-readiness-probe mismatch: %s", joined)
+	}
+	if joined := matchedNames(t, list, "get", "pods", "*", "--no-liveness-probe"); joined != "no-ready" {
+		t.Fatalf("--no-liveness-probe mismatch: %s", joined)
+	}
+}
+
+func TestLimit_OldestFirstAndThresholdOnLimitedSet(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -n web"] = `{"items":[` +
+		`{"metadata":{"name":"evicted-new","namespace":"web","creationTimestamp":"2025-05-03T00:00:00Z"}},` +
+		`{"metadata":{"name":"evicted-old","namespace":"web","creationTimestamp":"2025-05-01T00:00:00Z"}},` +
+		`{"metadata":{"name":"evicted-mid","namespace":"web","creationTimestamp":"2025-05-02T00:00:00Z"}}]}`
+	run := func(args ...string) (string, string) {
+		fr.calls = nil
+		opts, err := parseArgs(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		origStderr, origStdout := os.Stderr, os.Stdout
+		os.Stderr, os.Stdout = w, w
+		runErr := runCommand(fr, opts)
+		os.Stderr, os.Stdout = origStderr, origStdout
+		w.Close()
+		out, _ := io.ReadAll(r)
+		if runErr != nil {
+			t.Fatal(runErr)
+		}
+		return finalArgs(fr, "delete", "pods"), string(out)
+	}
+
+	got, out := run("delete", "pods", "evicted-*", "-n", "web", "--sort-by", "age", "--limit", "2", "-y")
+	if got != " evicted-old evicted-mid -n web " {
+		t.Fatalf("expected the two oldest pods deleted, got %q", got)
+	}
+	if !strings.Contains(out, "limiting to 2 of 3 matches") {
+		t.Fatalf("expected a limit note, got %q", out)
+	}
+	// Without a sort, discovery order decides
+	if got, _ := run("delete", "pods", "evicted-*", "-n", "web", "--limit", "1", "-y"); got != " evicted-new -n web " {
+		t.Fatalf("expected the first discovered pod, got %q", got)
+	}
+	// The confirm threshold counts the limited set: 2 <= 2 proceeds
+	if got, _ := run("delete", "pods", "evicted-*", "-n", "web", "--limit", "2", "--confirm-threshold", "2", "-y"); got != " evicted-new evicted-old -n web " {
+		t.Fatalf("expected the limited set to pass the threshold, got %q", got)
+	}
+}
+
+func TestReplicasUnready_PartiallyReadyDeployments(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get deploy -o json -A"] = `{"items":[` +
+		`{"metadata":{"name":"api","namespace":"prod"},"spec":{"replicas":3},"status":{"replicas":3,"readyReplicas":2}},` +
+		`{"metadata":{"name":"web","namespace":"prod"},"spec":{"replicas":3},"status":{"replicas":3,"readyReplicas":3}},` +
+		`{"metadata":{"name":"idle","namespace":"stage"},"spec":{"replicas":0},"status":{}}]}`
+	opts, err := parseArgs([]string{"get", "deploy", "-A", "--replicas-unready", "-q"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matched) != 1 || matched[0].ns != "prod" || matched[0].name != "api" {
+		t.Fatalf("expected only the 2/3 ready deployment, got %+v", matched)
+	}
+	if _, err := parseArgs([]string{"get", "pods", "*", "--replicas-unready"}); err == nil {
+		t.Fatalf("expected --replicas-unready on pods to fail")
+	}
+}
+
+// fileListRunner records the item names of the List passed to `get -f FILE`,
+// which is removed again once the call returns.
+type fileListRunner struct {
+	*fakeRunner
+	listed []string
+}
+
+func (r *fileListRunner) RunKubectl(args []string) error {
+	if len(args) > 2 && args[0] == "get" && args[1] == "-f" {
+		data, err := os.ReadFile(args[2])
+		if err != nil {
+			return err
+		}
+		var list struct {
+			Items []struct {
+				Metadata struct {
+					Name      string `json:"name"`
+					Namespace string `json:"namespace"`
+				} `json:"metadata"`
+			} `json:"items"`
+		}
+		if err := json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		for _, it := range list.Items {
+			r.listed = append(r.listed, it.Metadata.Namespace+"/"+it.Metadata.Name)
+		}
+	}
+	return r.fakeRunner.RunKubectl(args)
+}
+
+func TestSortBy_OrdersSingleTableAndNames(t *testing.T) {
+	pod := func(ns, name string, restarts int) string {
+		return fmt.Sprintf(`{"metadata":{"name":%q,"namespace":%q},"status":{"phase":"Running",`+
+			`"containerStatuses":[{"name":"app","ready":false,"restartCount":%d,"state":{"waiting":{"reason":"CrashLoopBackOff"}}}]}}`, name, ns, restarts)
+	}
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -A"] = `{"items":[` + pod("a", "api", 3) + "," + pod("b", "web", 12) + "," + pod("c", "db", 0) + `]}`
+	rec := &fileListRunner{fakeRunner: fr}
+	opts, err := parseArgs([]string{"get", "pods", "-A", "--unhealthy", "--sort-by=restarts", "--sort-reverse"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.SortBy != "restarts" || !opts.SortReverse || containsFlagWithPrefix(opts.FinalFlags, "--sort-by") {
+		t.Fatalf("unexpected parse: SortBy=%q SortReverse=%v FinalFlags=%v", opts.SortBy, opts.SortReverse, opts.FinalFlags)
+	}
+	if err := runCommand(rec, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(rec.listed, ","); got != "b/web,a/api,c/db" {
+		t.Fatalf("expected the single table in descending restarts, got %s", got)
+	}
+
+	// Names sort ascending, and namespace is a key too
+	opts, err = parseArgs([]string{"get", "pods", "-A", "--unhealthy", "--sort-by", "name", "-q"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sortMatched(matched, opts.SortBy, opts.SortReverse)
+	var buf bytes.Buffer
+	printNames(&buf, matched, true, false)
+	if got := buf.String(); got != "a/api\nc/db\nb/web\n" {
+		t.Fatalf("expected names in order, got %q", got)
+	}
+	sortMatched(matched, "namespace", true)
+	if matched[0].ns != "c" || matched[2].ns != "a" {
+		t.Fatalf("expected reverse namespace order, got %+v", matched)
+	}
+}
+
+func TestWait_BatchedKeepsWaitingAfterTimeout(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -n batch"] = discoveryJSON("job-a", "job-b", "job-c", "web")
+	fr.errs["wait pods job-a job-b -n batch --for=condition=Ready --timeout=60s"] = errors.New("timed out waiting for the condition")
+	opts, err := parseArgs([]string{"wait", "pods", "job-*", "-n", "batch", "--batch-size", "2", "--", "--for=condition=Ready", "--timeout=60s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected the timed-out batch to fail the run, got %v", err)
+	}
+	want := " job-a job-b -n batch --for=condition=Ready --timeout=60s job-c -n batch --for=condition=Ready --timeout=60s "
+	if got := finalArgs(fr, "wait", "pods"); got != want {
+		t.Fatalf("expected every matched pod to get a wait with the condition flags, got %q", got)
+	}
+}
+
+func TestFormatJSON_RoundTripsEnrichedFields(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -n prod"] = `{"items":[` +
+		`{"metadata":{"name":"web-1","namespace":"prod","labels":{"app":"web"},"ownerReferences":[{"kind":"ReplicaSet","name":"web-6d4f"}]},` +
+		`"spec":{"nodeName":"node-a"},"status":{"phase":"Running","containerStatuses":[` +
+		`{"name":"app","ready":false,"restartCount":4,"state":{"waiting":{"reason":"CrashLoopBackOff"}}}]}}]}`
+	opts, err := parseArgs([]string{"get", "pods", "web-*", "-n", "prod", "--format", "json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !opts.JSON {
+		t.Fatalf("expected --format json to select JSON output")
+	}
+	matched, err := discoverMatched(fr, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := printJSON(&buf, matched, false); err != nil {
+		t.Fatal(err)
+	}
+	var out struct {
+		WildVersion string     `json:"wildVersion"`
+		Items       []jsonItem `json:"items"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	restarts := 4
+	want := jsonItem{Namespace: "prod", Name: "web-1", Phase: "Running", Ready: "0/1", Restarts: &restarts, Node: "node-a",
+		Labels: map[string]string{"app": "web"}, Reasons: []string{"CrashLoopBackOff"}, Owners: []string{"ReplicaSet/web-6d4f"}}
+	if out.WildVersion != wildFormatVersion || len(out.Items) != 1 || !reflect.DeepEqual(out.Items[0], want) {
+		t.Fatalf("unexpected round trip:\n%s", buf.String())
+	}
+	// Re-encoding the decoded items reproduces the --bare array byte for byte
+	var bare, again bytes.Buffer
+	if err := printJSON(&bare, matched, true); err != nil {
+		t.Fatal(err)
+	}
+	enc := json.NewEncoder(&again)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out.Items); err != nil {
+		t.Fatal(err)
+	}
+	if again.String() != bare.String() {
+		t.Fatalf("round trip changed the output:\n%s\nvs\n%s", again.String(), bare.String())
+	}
+
+	if _, err := parseArgs([]string{"get", "pods", "*", "--format", "yaml"}); err == nil {
+		t.Fatalf("expected --format yaml to fail")
+	}
+}
+
+func TestGracePeriodLongerThan(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -n ns"] = `{"items":[` +
+		`{"metadata":{"name":"slow-drain","namespace":"ns"},"spec":{"terminationGracePeriodSeconds":300}},` +
+		`{"metadata":{"name":"default","namespace":"ns"},"spec":{}},` +
+		`{"metadata":{"name":"instant","namespace":"ns"},"spec":{"terminationGracePeriodSeconds":0}}]}`
+	run := func(seconds string) string {
+		fr.calls = nil
+		opts, err := parseArgs([]string{"get", "pods", "*", "-n", "ns", "--grace-period-longer-than", seconds})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := runCommand(fr, opts); err != nil {
+			t.Fatal(err)
+		}
+		return finalArgs(fr, "get", "pods")
+	}
+	if got := run("60"); got != " slow-drain -n ns " {
+		t.Fatalf("expected only the 300s pod, got %q", got)
+	}
+	// An absent field is kubernetes' default of 30s
+	if got := run("10"); got != " slow-drain default -n ns " {
+		t.Fatalf("expected the default grace period to count as 30s, got %q", got)
+	}
+	if _, err := parseArgs([]string{"get", "pods", "*", "--grace-period-longer-than", "30s"}); err == nil {
+		t.Fatalf("expected a non-integer value to fail")
+	}
+}
+
+func TestColorDefaultsToTerminalAndNoColor(t *testing.T) {
+	orig := stdoutIsTerminal
+	defer func() { stdoutIsTerminal = orig }()
+	parse := func(args ...string) CLIOptions {
+		opts, err := parseArgs(append([]string{"delete", "pods", "web-*"}, args...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return opts
+	}
+
+	stdoutIsTerminal = func() bool { return true }
+	t.Setenv("NO_COLOR", "")
+	if parse().NoColor {
+		t.Fatalf("expected color on a terminal")
+	}
+	if !parse("--no-color").NoColor {
+		t.Fatalf("expected --no-color to disable color on a terminal")
+	}
+	t.Setenv("NO_COLOR", "1")
+	if !parse().NoColor {
+		t.Fatalf("expected NO_COLOR to disable color")
+	}
+
+	stdoutIsTerminal = func() bool { return false }
+	t.Setenv("NO_COLOR", "")
+	if !parse().NoColor {
+		t.Fatalf("expected no color when stdout is piped")
+	}
+	if parse("--color=always").NoColor || parse("--color", "always").NoColor {
+		t.Fatalf("expected --color=always to force color when piped")
+	}
+	if !parse("--color=always", "--no-color").NoColor {
+		t.Fatalf("expected the last of --color=always and --no-color to win")
+	}
+	if _, err := parseArgs([]string{"get", "pods", "--color=sometimes"}); err == nil {
+		t.Fatalf("expected an unknown --color mode to fail")
+	}
+}
+
+func TestSelectivity_FunnelCounts(t *testing.T) {
+	pod := func(ns, name, app, node, phase string) string {
+		return fmt.Sprintf(`{"metadata":{"name":%q,"namespace":%q,"labels":{"app":%q}},"spec":{"nodeName":%q},"status":{"phase":%q}}`,
+			name, ns, app, node, phase)
+	}
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -A"] = `{"items":[` + strings.Join([]string{
+		pod("a", "web-1", "web", "node-a", "Running"), // survives every stage
+		pod("b", "web-2", "web", "node-a", "Running"), // dropped by namespace
+		pod("a", "db-1", "web", "node-a", "Running"),  // dropped by name
+		pod("a", "web-3", "api", "node-a", "Running"), // dropped by labels (a glob, so not pushed down as -l)
+		pod("a", "web-4", "web", "node-b", "Running"), // dropped by node
+		pod("a", "web-5", "web", "node-a", "Pending"), // dropped by status
+	}, ",") + `]}`
+	opts, err := parseArgs([]string{"get", "pods", "web-*", "-A", "--ns", "a", "--label", "app=web*", "--node", "node-a", "--status", "Running", "--selectivity"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	origStderr := os.Stderr
+	os.Stderr = w
+	matched, err := discoverMatched(fr, &opts)
+	os.Stderr = origStderr
+	w.Close()
+	out, _ := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matched) != 1 || matched[0].name != "web-1" {
+		t.Fatalf("expected only web-1 to match, got %+v", matched)
+	}
+	want := map[string][3]int{
+		"namespace":   {6, 5, 1},
+		"name":        {5, 4, 1},
+		"labels":      {4, 3, 1},
+		"annotations": {3, 3, 0},
+		"metadata":    {3, 3, 0},
+		"age":         {3, 3, 0},
+		"node":        {3, 2, 1},
+		"spec":        {2, 2, 0},
+		"status":      {2, 1, 1},
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		f := strings.Fields(line)
+		if len(f) != 4 {
+			continue
+		}
+		counts, ok := want[f[0]]
+		if !ok {
+			continue
+		}
+		if got := fmt.Sprintf("%s %s %s", f[1], f[2], f[3]); got != fmt.Sprintf("%d %d %d", counts[0], counts[1], counts[2]) {
+			t.Fatalf("stage %s: expected in/out/dropped %v, got %s\n%s", f[0], counts, got, out)
+		}
+		delete(want, f[0])
+	}
+	if len(want) != 0 {
+		t.Fatalf("missing stages %v in:\n%s", want, out)
+	}
+}
+
+func TestGroupByLabel_MultipleKeys(t *testing.T) {
+	opts, err := parseArgs([]string{"get", "pods", "-A", "--group-by-label", "team", "--group-by-label", "app", "--colorize-labels"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(opts.GroupByLabel, []string{"team", "app"}) {
+		t.Fatalf("expected both keys, got %v", opts.GroupByLabel)
+	}
+
+	tmp, err := os.CreateTemp("", "wild-summary-*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmp.Name())
+	matched := []matchedRef{
+		{ns: "a", name: "1", labels: map[string]string{"team": "payments", "app": "web"}},
+		{ns: "a", name: "2", labels: map[string]string{"team": "payments", "app": "web"}},
+		{ns: "b", name: "3", labels: map[string]string{"team": "payments", "app": "api"}},
+		{ns: "b", name: "4", labels: map[string]string{"app": "web"}},
+	}
+	opts.NoColor = true
+	printLabelSummary(tmp, opts, matched)
+	tmp.Close()
+	data, err := os.ReadFile(tmp.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Grouping by labels team, app:\n" +
+		"team=payments, app=web → 2\n" +
+		"team=(none), app=web → 1\n" +
+		"team=payments, app=api → 1\n" +
+		"Added -L team -L app to kubectl output.\n"
+	if s := string(data); s != want {
+		t.Fatalf("unexpected summary:\n%s\nwant:\n%s", s, want)
+	}
+
+	// Passthrough adds a column per key too
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	if err := runVerbPassthrough(fr, CLIOptions{Verb: VerbGet, Resource: "pods", GroupByLabel: []string{"team", "app"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(fr.calls) != 1 || !equalSlices(fr.calls[0], []string{"get", "pods", "-L", "team", "-L", "app"}) {
+		t.Fatalf("expected -L per key, got %v", fr.calls)
+	}
+}
+
+func TestGroupByOwner_SummaryToStderr(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	crash := `"status":{"phase":"Running","containerStatuses":[{"name":"app","ready":false,"restartCount":5,"state":{"waiting":{"reason":"CrashLoopBackOff"}}}]}`
+	fr.outputs["get pods -o json -A"] = `{"items":[` +
+		`{"metadata":{"name":"web-6d4f-a","namespace":"prod","ownerReferences":[{"kind":"ReplicaSet","name":"web-6d4f"}]},` + crash + `},` +
+		`{"metadata":{"name":"web-6d4f-b","namespace":"prod","ownerReferences":[{"kind":"ReplicaSet","name":"web-6d4f"}]},` + crash + `},` +
+		`{"metadata":{"name":"db-0","namespace":"prod","ownerReferences":[{"kind":"StatefulSet","name":"db"}]},` + crash + `},` +
+		`{"metadata":{"name":"debug","namespace":"dev"},` + crash + `},` +
+		`{"metadata":{"name":"api-1","namespace":"prod","ownerReferences":[{"kind":"ReplicaSet","name":"api-1"}]},"status":{"phase":"Running"}}]}`
+	opts, err := parseArgs([]string{"get", "pods", "-A", "--reason", "CrashLoopBackOff", "--group-by-owner"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	origStderr := os.Stderr
+	os.Stderr = w
+	runErr := runCommand(fr, opts)
+	os.Stderr = origStderr
+	w.Close()
+	out, _ := io.ReadAll(r)
+	if runErr != nil {
+		t.Fatal(runErr)
+	}
+	want := "Grouping by owner:\n" +
+		"prod/ReplicaSet/web-6d4f → 2\n" +
+		"dev/(orphan) → 1\n" +
+		"prod/StatefulSet/db → 1\n"
+	if !strings.Contains(string(out), want) {
+		t.Fatalf("expected owner summary %q, got:\n%s", want, out)
+	}
+	if fr.calls[len(fr.calls)-1][0] != "get" {
+		t.Fatalf("expected the table to still be printed, got %v", fr.calls)
+	}
+}
+
+func TestLabelNumericComparisons(t *testing.T) {
+	list := `{"items":[` +
+		`{"metadata":{"name":"ten","namespace":"ns","labels":{"revision":"10"},"annotations":{"deployment.kubernetes.io/revision":"10"}}},` +
+		`{"metadata":{"name":"three","namespace":"ns","labels":{"revision":"3"},"annotations":{"deployment.kubernetes.io/revision":"3"}}},` +
+		`{"metadata":{"name":"five","namespace":"ns","labels":{"revision":"5"}}},` +
+		`{"metadata":{"name":"latest","namespace":"ns","labels":{"revision":"latest"}}}]}`
+	// Non-numeric values never match
+	for flag, want := range map[string]string{
+		"--label-gt": "ten",
+		"--label-ge": "ten,five",
+		"--label-lt": "three",
+		"--label-le": "three,five",
+		"--label-eq": "five",
+	} {
+		if got := matchedNames(t, list, "get", "deploy", "*", "-n", "ns", flag, "revision=5"); got != want {
+			t.Fatalf("%s revision=5: expected %q, got %q", flag, want, got)
+		}
+	}
+	if got := matchedNames(t, list, "get", "deploy", "*", "-n", "ns", "--annotation-gt", "deployment.kubernetes.io/revision=4"); got != "ten" {
+		t.Fatalf("--annotation-gt: expected only ten, got %q", got)
+	}
+	if _, err := parseArgs([]string{"get", "deploy", "--label-gt", "revision=five"}); err == nil {
+		t.Fatalf("expected a non-integer threshold to fail")
+	}
+}
+
+func TestLabelInAndNotIn(t *testing.T) {
+	list := `{"items":[` +
+		`{"metadata":{"name":"prod","namespace":"ns","labels":{"env":"prod","tier":"web"}}},` +
+		`{"metadata":{"name":"staging","namespace":"ns","labels":{"env":"staging","tier":"db"}}},` +
+		`{"metadata":{"name":"dev","namespace":"ns","labels":{"env":"dev","tier":"web"}}},` +
+		`{"metadata":{"name":"unlabeled","namespace":"ns"}}]}`
+	if got := matchedNames(t, list, "get", "pods", "*", "-n", "ns", "--label-in", "env=prod,staging"); got != "prod,staging" {
+		t.Fatalf("--label-in: got %q", got)
+	}
+	// notin also keeps objects without the key, like a Kubernetes selector
+	if got := matchedNames(t, list, "get", "pods", "*", "-n", "ns", "--label-notin", "env=prod,staging"); got != "dev,unlabeled" {
+		t.Fatalf("--label-notin: got %q", got)
+	}
+	// AND across keys
+	if got := matchedNames(t, list, "get", "pods", "*", "-n", "ns", "--label-in", "env=staging,dev", "--label", "tier=web*"); got != "dev" {
+		t.Fatalf("AND across keys: got %q", got)
+	}
+	// OR within a key
+	if got := matchedNames(t, list, "get", "pods", "*", "-n", "ns", "--label-in", "env=prod", "--label-notin", "env=prod,staging"); got != "prod,dev,unlabeled" {
+		t.Fatalf("OR within a key: got %q", got)
+	}
+	if got := matchedNames(t, list, "get", "pods", "*", "-n", "ns", "--label-in", "env=PROD", "--ignore-case"); got != "prod" {
+		t.Fatalf("--label-in with --ignore-case: got %q", got)
+	}
+	if _, err := parseArgs([]string{"get", "pods", "--label-in", "env="}); err == nil {
+		t.Fatalf("expected an empty set to fail")
+	}
+}
+
+func TestHasLabelAndNoLabel(t *testing.T) {
+	list := `{"items":[` +
+		`{"metadata":{"name":"injected","namespace":"a","labels":{"istio.io/rev":"1-20","app":"web"},"annotations":{"sidecar.istio.io/status":"{}"}}},` +
+		`{"metadata":{"name":"empty-rev","namespace":"a","labels":{"istio.io/rev":"","app":"web"}}},` +
+		`{"metadata":{"name":"plain","namespace":"b","labels":{"app":"web"}}},` +
+		`{"metadata":{"name":"bare","namespace":"b"}}]}`
+	// Any value counts, including an empty one
+	if got := matchedNames(t, list, "get", "pods", "-A", "--has-label", "istio.io/rev"); got != "injected,empty-rev" {
+		t.Fatalf("--has-label: got %q", got)
+	}
+	// Objects without any labels (a nil map) lack the key too
+	if got := matchedNames(t, list, "get", "pods", "-A", "--no-label", "istio.io/rev"); got != "plain,bare" {
+		t.Fatalf("--no-label: got %q", got)
+	}
+	if got := matchedNames(t, list, "get", "pods", "-A", "--has-label", "app", "--no-label", "istio.io/rev"); got != "plain" {
+		t.Fatalf("--has-label with --no-label: got %q", got)
+	}
+	if got := matchedNames(t, list, "get", "pods", "-A", "--has-annotation", "sidecar.istio.io/status"); got != "injected" {
+		t.Fatalf("--has-annotation: got %q", got)
+	}
+	if got := matchedNames(t, list, "get", "pods", "-A", "--no-annotation", "sidecar.istio.io/status", "--label", "app=w*"); got != "empty-rev,plain" {
+		t.Fatalf("--no-annotation: got %q", got)
+	}
+}
+
+func TestLabelNegation(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -n ns"] = `{"items":[` +
+		`{"metadata":{"name":"web","namespace":"ns","labels":{"app":"web"}}},` +
+		`{"metadata":{"name":"api","namespace":"ns","labels":{"app":"api","debug":"true"}}},` +
+		`{"metadata":{"name":"bare","namespace":"ns"}}]}`
+	// An exact key=value is also pushed down as a selector
+	fr.outputs["get pods -o json -n ns -l app=web"] = `{"items":[{"metadata":{"name":"web","namespace":"ns","labels":{"app":"web"}}}]}`
+	run := func(selector string) string {
+		fr.calls = nil
+		opts, err := parseArgs([]string{"get", "pods", "*", "-n", "ns", "--label", selector})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := runCommand(fr, opts); err != nil {
+			t.Fatal(err)
+		}
+		return finalArgs(fr, "get", "pods")
+	}
+	// The pod labeled app=web matches key=glob, survives !debug, and fails app!=web
+	if got := run("app=web"); got != " web -n ns " {
+		t.Fatalf("app=web: got %q", got)
+	}
+	if got := run("!debug"); got != " web bare -n ns " {
+		t.Fatalf("!debug: got %q", got)
+	}
+	// key!=value requires the key, unlike kubectl's selector
+	if got := run("app!=web"); got != " api -n ns " {
+		t.Fatalf("app!=web: got %q", got)
+	}
+	for _, bad := range []string{"!", "!debug=true", "!=web"} {
+		if _, err := parseArgs([]string{"get", "pods", "--label", bad}); err == nil {
+			t.Fatalf("expected --label %q to fail", bad)
+		}
+	}
+}
+
+func TestImageFilters(t *testing.T) {
+	list := `{"items":[` +
+		`{"metadata":{"name":"legacy","namespace":"a"},"spec":{"containers":[{"name":"app","image":"registry.example.com/app:2.1"},{"name":"proxy","image":"nginx:1.19"}]}},` +
+		`{"metadata":{"name":"hub","namespace":"a"},"spec":{"containers":[{"name":"app","image":"docker.io/library/redis:7"}]}},` +
+		`{"metadata":{"name":"init-only","namespace":"b"},"spec":{"initContainers":[{"name":"setup","image":"docker.io/busybox:1.36"}],"containers":[{"name":"app","image":"registry.example.com/app:2.1"}]}},` +
+		`{"metadata":{"name":"debugged","namespace":"b"},"spec":{"containers":[{"name":"app","image":"registry.example.com/app:2.2"}],"ephemeralContainers":[{"name":"debugger","image":"nginx:1.19"}]}},` +
+		`{"metadata":{"name":"current","namespace":"b"},"spec":{"containers":[{"name":"app","image":"registry.example.com/app:2.2"}]}}]}`
+	// Any container counts, including init and ephemeral ones; * spans '/'
+	if got := matchedNames(t, list, "get", "pods", "-A", "--image", "nginx:1.19"); got != "legacy,debugged" {
+		t.Fatalf("--image nginx:1.19: got %q", got)
+	}
+	if got := matchedNames(t, list, "get", "pods", "-A", "--image", "docker.io/*"); got != "hub,init-only" {
+		t.Fatalf("--image docker.io/*: got %q", got)
+	}
+	if got := matchedNames(t, list, "get", "pods", "-A", "--image-regex", `:2\.1$`, "--ns", "b"); got != "init-only" {
+		t.Fatalf("--image-regex with --ns: got %q", got)
+	}
+	if got := matchedNames(t, list, "get", "pods", "-A", "--image", "docker.io/*", "--image", "nginx:*"); got != "legacy,hub,init-only,debugged" {
+		t.Fatalf("repeated --image: got %q", got)
+	}
+	if _, err := parseArgs([]string{"get", "pods", "--image-regex", "("}); err == nil {
+		t.Fatalf("expected an invalid regex to fail")
+	}
+}
+
+func TestQoSFilter(t *testing.T) {
+	list := `{"items":[` +
+		`{"metadata":{"name":"db","namespace":"a"},"status":{"phase":"Running","qosClass":"Guaranteed"}},` +
+		`{"metadata":{"name":"web","namespace":"a"},"status":{"phase":"Running","qosClass":"Burstable"}},` +
+		`{"metadata":{"name":"batch","namespace":"b"},"status":{"phase":"Running","qosClass":"BestEffort"}},` +
+		`{"metadata":{"name":"new","namespace":"b"},"status":{"phase":"Pending"}}]}`
+	if got := matchedNames(t, list, "get", "pods", "-A", "--qos", "BestEffort"); got != "batch" {
+		t.Fatalf("--qos BestEffort: got %q", got)
+	}
+	if got := matchedNames(t, list, "get", "pods", "-A", "--qos", "besteffort", "--qos", "BURSTABLE"); got != "web,batch" {
+		t.Fatalf("repeated case-insensitive --qos: got %q", got)
+	}
+	if _, err := parseArgs([]string{"get", "pods", "--qos", "Premium"}); err == nil {
+		t.Fatalf("expected an unknown QoS class to fail")
+	}
+}
+
+func TestCondition_NodesReadyFalseOrUnknown(t *testing.T) {
+	list := `{"items":[
+		{"metadata":{"name":"node-a"},"status":{"conditions":[{"type":"Ready","status":"True"}]}},
+		{"metadata":{"name":"node-b"},"status":{"conditions":[{"type":"Ready","status":"False"},{"type":"DiskPressure","status":"True"}]}},
+		{"metadata":{"name":"node-c"},"status":{"conditions":[{"type":"Ready","status":"Unknown","reason":"NodeStatusUnknown"}]}}]}`
+	if got := matchedNames(t, list, "get", "nodes", "*", "--condition", "Ready=False"); got != "node-b" {
+		t.Fatalf("Ready=False: got %q", got)
+	}
+	// A node whose kubelet stopped reporting has Ready=Unknown
+	if got := matchedNames(t, list, "get", "nodes", "*", "--condition", "Ready=unknown"); got != "node-c" {
+		t.Fatalf("Ready=Unknown: got %q", got)
+	}
+	// Repeated conditions are ANDed
+	if got := matchedNames(t, list, "get", "nodes", "*", "--condition", "Ready=False", "--condition", "DiskPressure=False"); got != "" {
+		t.Fatalf("expected no node with Ready=False and DiskPressure=False, got %q", got)
+	}
+}
+
+func TestNodeConditionShortcuts(t *testing.T) {
+	list := `{"items":[
+		{"metadata":{"name":"node-a"},"status":{"conditions":[{"type":"Ready","status":"True"},{"type":"MemoryPressure","status":"False"}]}},
+		{"metadata":{"name":"node-b"},"status":{"conditions":[{"type":"Ready","status":"True"},{"type":"DiskPressure","status":"True"}]}},
+		{"metadata":{"name":"node-c"},"status":{"conditions":[{"type":"Ready","status":"Unknown"},{"type":"PIDPressure","status":"True"}]}},
+		{"metadata":{"name":"node-d"},"status":{}}]}`
+	// Unknown and missing Ready both count as not ready
+	if got := matchedNames(t, list, "describe", "nodes", "--node-not-ready"); got != "node-c,node-d" {
+		t.Fatalf("--node-not-ready: got %q", got)
+	}
+	if got := matchedNames(t, list, "describe", "nodes", "--node-disk-pressure"); got != "node-b" {
+		t.Fatalf("--node-disk-pressure: got %q", got)
+	}
+	if got := matchedNames(t, list, "describe", "nodes", "--node-memory-pressure", "--node-pid-pressure"); got != "node-c" {
+		t.Fatalf("--node-memory-pressure --node-pid-pressure: got %q", got)
+	}
+	if got := matchedNames(t, list, "describe", "nodes", "--node-pressure"); got != "node-b,node-c" {
+		t.Fatalf("--node-pressure: got %q", got)
+	}
+	if got := matchedNames(t, list, "describe", "nodes", "--node-pressure", "--node-not-ready"); got != "node-c" {
+		t.Fatalf("--node-pressure --node-not-ready: got %q", got)
+	}
+	for _, args := range [][]string{
+		{"get", "pods", "-A", "--node-not-ready"},
+		{"get", "deploy", "*", "--node-memory-pressure"},
+	} {
+		if _, err := parseArgs(args); err == nil || !strings.Contains(err.Error(), "only apply to nodes") {
+			t.Fatalf("%v: expected nodes-only error, got %v", args, err)
+		}
+	}
+}
+
+func TestPodIPAndHostIPFilters(t *testing.T) {
+	list := `{"items":[
+		{"metadata":{"name":"web-1","namespace":"prod"},"status":{"phase":"Running","podIP":"10.244.3.17","hostIP":"10.0.1.5"}},
+		{"metadata":{"name":"web-2","namespace":"prod"},"status":{"phase":"Running","podIP":"10.244.30.2","hostIP":"10.0.2.9"}},
+		{"metadata":{"name":"web-3","namespace":"prod"},"status":{"phase":"Running","podIP":"10.245.0.4","hostIP":"10.0.1.6"}},
+		{"metadata":{"name":"web-4","namespace":"prod"},"status":{"phase":"Pending"}}]}`
+	// Glob '.' is literal, so 10.244.3.* does not match 10.244.30.2
+	if got := matchedNames(t, list, "get", "pods", "web-*", "-n", "prod", "--pod-ip", "10.244.3.*"); got != "web-1" {
+		t.Fatalf("--pod-ip glob: got %q", got)
+	}
+	if got := matchedNames(t, list, "get", "pods", "web-*", "-n", "prod", "--pod-ip", "10.244.0.0/16"); got != "web-1,web-2" {
+		t.Fatalf("--pod-ip CIDR: got %q", got)
+	}
+	if got := matchedNames(t, list, "get", "pods", "web-*", "-n", "prod", "--pod-ip", "10.245.0.4/32", "--pod-ip", "10.244.30.*"); got != "web-2,web-3" {
+		t.Fatalf("repeated --pod-ip: got %q", got)
+	}
+	if got := matchedNames(t, list, "get", "pods", "web-*", "-n", "prod", "--host-ip", "10.0.1.0/24"); got != "web-1,web-3" {
+		t.Fatalf("--host-ip CIDR: got %q", got)
+	}
+	if got := matchedNames(t, list, "get", "pods", "web-*", "-n", "prod", "--host-ip", "10.0.1.*", "--pod-ip", "10.244.0.0/16"); got != "web-1" {
+		t.Fatalf("--host-ip with --pod-ip: got %q", got)
+	}
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"get", "pods", "-A", "--pod-ip", "10.244.0.0/33"}, "bad CIDR"},
+		{[]string{"get", "pods", "-A", "--host-ip", "10.0.[1"}, "bad glob"},
+		{[]string{"get", "svc", "-A", "--pod-ip", "10.244.0.0/16"}, "only apply to pods"},
+	} {
+		if _, err := parseArgs(tc.args); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%v: expected error containing %q, got %v", tc.args, tc.want, err)
+		}
+	}
+}
+
+func TestRemoveFinalizers_UsesConnectionFlags(t *testing.T) {
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["get pods -o json -n ns --context prod --kubeconfig=/tmp/kc"] = "{\"items\":[" +
+		"{\"metadata\":{\"name\":\"stuck-1\",\"namespace\":\"ns\",\"finalizers\":[\"example.com/x\"]}}]}"
+	opts, err := parseArgs([]string{"delete", "pods", "stuck-*", "-n", "ns", "--context", "prod", "--kubeconfig=/tmp/kc", "--remove-finalizers", "-y", "--no-color"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	var patch, del []string
+	for _, c := range fr.calls {
+		switch {
+		case len(c) > 0 && c[0] == "patch":
+			patch = c
+		case len(c) > 2 && c[0] == "delete":
+			del = c
+		}
+	}
+	want := []string{"patch", "pods", "stuck-1", "--type=merge", "-p", `{"metadata":{"finalizers":null}}`, "-n", "ns", "--context", "prod", "--kubeconfig=/tmp/kc"}
+	if !reflect.DeepEqual(patch, want) {
+		t.Fatalf("patch must target the same cluster as the delete: got %v; calls=%v", patch, fr.calls)
+	}
+	if !containsFlag(del, "prod") {
+		t.Fatalf("expected delete with --context prod, got %v", del)
+	}
+}
+
+func TestPodOnlyFilters_PodShortNames(t *testing.T) {
+	list := `{"items":[
+		{"metadata":{"name":"db-0","namespace":"prod"},
+		 "spec":{"volumes":[{"name":"d","persistentVolumeClaim":{"claimName":"data-db-0"}}],"containers":[{"name":"c","image":"postgres:16"}]},
+		 "status":{"phase":"Running","qosClass":"Guaranteed","containerStatuses":[{"name":"c","ready":true,"restartCount":4}]}},
+		{"metadata":{"name":"web-0","namespace":"prod"},
+		 "spec":{"containers":[{"name":"c","image":"nginx:1.25"}]},
+		 "status":{"phase":"Running","qosClass":"BestEffort","containerStatuses":[{"name":"c","ready":true,"restartCount":0}]}}]}`
+	for _, resource := range []string{"po", "pod", "pods"} {
+		fr := &fakeRunner{outputs: map[string]string{"get " + resource + " -o json -n prod": list}, errs: map[string]error{}}
+		for _, filter := range [][]string{
+			{"--uses-pvc", "data-*"},
+			{"--qos", "Guaranteed"},
+			{"--image", "postgres*"},
+			{"--restarts", ">2"},
+		} {
+			opts, err := parseArgs(append([]string{"delete", resource, "*", "-n", "prod"}, filter...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			matched, err := discoverMatched(fr, &opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(matched) != 1 || matched[0].name != "db-0" {
+				t.Fatalf("%s %v: expected only db-0, got %v", resource, filter, matched)
+			}
+		}
+	}
+}
+
+func TestLabelAndAnnotate_RetryConflict(t *testing.T) {
+	origSleep := retrySleep
+	defer func() { retrySleep = origSleep }()
+	retrySleep = func(time.Duration) {}
+	conflict := `Error from server (Conflict): Operation cannot be fulfilled on pods "canary-a": the object has been modified; please apply your changes to the latest version and try again`
+	for _, argv := range [][]string{
+		{"label", "pods", "canary-*", "tier=canary", "--overwrite", "-n", "web", "-y", "--retry-conflict", "2"},
+		{"annotate", "pods", "canary-*", "note=x", "-n", "web", "-y", "--retry-conflict", "2"},
+	} {
+		fr := &flakyRunner{fakeRunner: fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}, failures: 1, stderr: conflict, verb: argv[0]}
+		fr.outputs["get pods -o json -n web"] = discoveryJSON("canary-a", "canary-b")
+		opts, err := parseArgs(argv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := runCommand(fr, opts); err != nil {
+			t.Fatalf("%s: expected success after retrying the conflict, got %v", argv[0], err)
+		}
+		attempts := 0
+		for _, c := range fr.calls {
+			if c[0] == argv[0] {
+				attempts++
+			}
+		}
+		if attempts != 2 {
+			t.Fatalf("%s: expected 2 attempts, got %d; calls=%v", argv[0], attempts, fr.calls)
+		}
+	}
+}
+
+func TestPatch_RetryConflict(t *testing.T) {
+	origSleep := retrySleep
+	defer func() { retrySleep = origSleep }()
+	retrySleep = func(time.Duration) {}
+	conflict := `Error from server (Conflict): Operation cannot be fulfilled on deployments.apps "web-a": the object has been modified; please apply your changes to the latest version and try again`
+	fr := &flakyRunner{fakeRunner: fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}, failures: 1, stderr: conflict, verb: "patch"}
+	fr.outputs["get deploy -o json -n prod"] = discoveryJSON("web-a", "web-b")
+	opts, err := parseArgs([]string{"patch", "deploy", "web-*", "-n", "prod", "-y", "--retry-conflict", "1", "--", "--type=merge", "-p", "{}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatalf("expected success after retrying the conflict, got %v", err)
+	}
+	var patched []string
+	for _, c := range fr.calls {
+		if c[0] == "patch" {
+			patched = append(patched, c[2])
+		}
+	}
+	if strings.Join(patched, ",") != "web-a,web-a,web-b" {
+		t.Fatalf("expected web-a retried once then web-b, got %v", patched)
+	}
+}
+
+func TestSortBy_KeepsOrderForNonGetVerbsAcrossNamespaces(t *testing.T) {
+	pod := func(ns, name, created string) string {
+		return fmt.Sprintf(`{"metadata":{"name":%q,"namespace":%q,"creationTimestamp":%q}}`, name, ns, created)
+	}
+	fr := &fakeRunner{outputs: map[string]string{}, errs: map[string]error{}}
+	fr.outputs["api-resources -o name --verbs=list --namespaced=true"] = "pods\n"
+	fr.outputs["get pods -o json -A"] = `{"items":[` +
+		pod("a", "alpha", "2023-01-01T00:00:00Z") + "," +
+		pod("a", "beta", "2021-01-01T00:00:00Z") + "," +
+		pod("a", "omega", "2024-01-01T00:00:00Z") + "," +
+		pod("b", "zeta", "2020-01-01T00:00:00Z") + `]}`
+	opts, err := parseArgs([]string{"delete", "pods", "-A", "--sort-by", "age", "--limit", "3", "-y", "--no-color"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(fr, opts); err != nil {
+		t.Fatal(err)
+	}
+	var deletes []string
+	for _, c := range fr.calls {
+		if len(c) > 2 && c[0] == "delete" {
+			deletes = append(deletes, strings.Join(c, " "))
+		}
+	}
+	want := []string{"delete pods zeta -n b", "delete pods beta alpha -n a"}
+	if len(deletes) != len(want) {
+		t.Fatalf("expected %v, got %v", want, deletes)
+	}
+	for i := range want {
+		if !strings.HasPrefix(deletes[i], want[i]) {
+			t.Fatalf("expected oldest first %v, got %v", want, deletes)
+		}
+	}
+}
+
+func TestKubeconfigs_RejectsStructuredOutput(t *testing.T) {
+	for _, argv := range [][]string{
+		{"get", "pods", "*", "--kubeconfigs", "a,b", "--json"},
+		{"get", "pods", "*", "--kubeconfigs", "a,b", "--format", "json"},
+		{"get", "pods", "*", "--kubeconfigs", "a,b", "-q"},
+		{"get", "pods", "*", "--kubeconfigs", "a,b", "--metrics"},
+	} {
+		if _, err := parseArgs(argv); err == nil || !strings.Contains(err.Error(), "--kubeconfigs can't be combined") {
+			t.Fatalf("%v: expected --kubeconfigs rejection, got %v", argv, err)
+		}
+	}
+	if _, err := parseArgs([]string{"get", "pods", "*", "--kubeconfigs", "a,b"}); err != nil {
+		t.Fatalf("plain table output must stay allowed: %v", err)
+	}
+}
+
+func TestParseArgs_PodOnlyFiltersRejectOtherResources(t *testing.T) {
+	for _, filter := range [][]string{
+		{"--uses-pvc", "data-*"},
+		{"--uses-configmap", "app-config"},
+		{"--uses-secret", "tls"},
+		{"--qos", "BestEffort"},
+		{"--image", "nginx:1.19"},
+		{"--image-regex", "^nginx:"},
+		{"--no-readiness-probe"},
+		{"--grace-period-longer-than", "60"},
+		{"--has-affinity"},
+		{"--tolerates", "dedicated"},
+		{"--node-selector", "disk=ssd"},
+		{"--no-node-selector"},
+		{"--restart-policy", "Never"},
+		{"--unscheduled"},
+		{"--scheduling-gated"},
+		{"--churning"},
+	} {
+		argv := append([]string{"delete", "deploy", "*", "-y"}, filter...)
+		if _, err := parseArgs(argv); err == nil || !strings.Contains(err.Error(), filter[0]+" only applies to pods") {
+			t.Fatalf("%v: expected pods-only error, got %v", filter, err)
+		}
+		argv = append([]string{"delete", "po", "*", "-y"}, filter...)
+		if _, err := parseArgs(argv); err != nil {
+			t.Fatalf("%v on po: %v", filter, err)
+		}
+	}
+}
+
+func TestParseArgs_ChurningAgeAcceptsDays(t *testing.T) {
+	for arg, want := range map[string]time.Duration{"7d": 7 * 24 * time.Hour, "1d12h": 36 * time.Hour, "72h": 72 * time.Hour} {
+		opts, err := parseArgs([]string{"get", "pods", "-A", "--churning", "--churning-age", arg})
+		if err != nil {
+			t.Fatalf("%s: %v", arg, err)
+		}
+		if opts.ChurningAge != want {
+			t.Fatalf("%s: got %v want %v", arg, opts.ChurningAge, want)
+		}
+	}
+	if _, err := parseArgs([]string{"get", "pods", "-A", "--churning", "--churning-age", "soon"}); err == nil {
+		t.Fatal("expected an invalid --churning-age to fail")
+	}
+}
+
+func TestParseArgs_ReadyFlappedWithinAcceptsDays(t *testing.T) {
+	opts, err := parseArgs([]string{"get", "pods", "-A", "--ready-flapped-within", "1d"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.ReadyFlappedWithin != 24*time.Hour {
+		t.Fatalf("expected 24h, got %v", opts.ReadyFlappedWithin)
+	}
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"path"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 // Pool for levenshtein row slices to reduce allocations in fuzzy matching hot path
@@ -25,18 +28,48 @@ type levenshteinRows struct {
 }
 
 type NameRef struct {
+	Kind               string
 	Namespace          string
 	Name               string
 	CreatedAt          time.Time
 	PodReasons         []string
 	PodPhase           string
+	PodIP              string
+	HostIP             string
+	Status             string // kubectl's STATUS column, e.g. CrashLoopBackOff
 	Labels             map[string]string
 	Annotations        map[string]string
 	NodeName           string
 	TotalRestarts      int
 	NotReadyContainers int
+	TotalContainers    int
+	ReadyContainers    int       // TotalContainers - NotReadyContainers
+	ReadyTransitionAt  time.Time // Ready condition lastTransitionTime
+	DeletionTimestamp  time.Time // set while the object is terminating
 	ReasonsByContainer map[string][]string
+	Conditions         map[string]string
 	Owners             []string // Kind/Name pairs like Deployment/web-1
+	SchedulingGates    []string // spec.schedulingGates names
+	Finalizers         []string // metadata.finalizers
+	NodeSelector       map[string]string
+	HasAffinity        bool
+	ScaledToZero       bool      // spec.replicas and status.replicas both 0
+	ReplicasUnready    bool      // status.readyReplicas < spec.replicas
+	MissingReadiness   bool      // some container has no readinessProbe
+	MissingLiveness    bool      // some container has no livenessProbe
+	Tolerations        []string  // tolerated taint keys; "*" when all taints are tolerated
+	LastRestartAt      time.Time // latest container lastState.terminated.finishedAt
+	RestartPolicy      string    // spec.restartPolicy
+	QOSClass           string    // status.qosClass
+	GracePeriodSeconds int64     // spec.terminationGracePeriodSeconds (30 when unset)
+	Managers           []string  // metadata.managedFields[].manager
+	PVCs               []string  // spec.volumes[].persistentVolumeClaim.claimName
+	ConfigMaps         []string  // ConfigMaps referenced by volumes, envFrom and env valueFrom
+	Secrets            []string  // Secrets referenced by volumes, envFrom, env valueFrom and imagePullSecrets
+	Images             []string  // images of init, app and ephemeral containers
+
+	// The item as returned by discovery (a subslice of the list output)
+	Raw json.RawMessage
 }
 
 type Matcher struct {
@@ -51,6 +84,9 @@ type Matcher struct {
 	NsExact  []string
 	NsPrefix []string
 	NsRegex  []*regexp.Regexp // Pre-compiled regexes
+	// Namespace excludes, checked before (and winning over) the filters above
+	NsExclude       []string
+	NsExcludePrefix []string
 	// Pre-computed: map for fast exact namespace lookup (only populated if many exact namespaces)
 	NsExactMap map[string]bool
 	// Fuzzy
@@ -59,6 +95,8 @@ type Matcher struct {
 	// Label filters
 	LabelFilters  []LabelFilter
 	LabelKeyRegex []*regexp.Regexp // Pre-compiled regexes
+	// Exact keys that must be present (any value) / absent
+	HasLabels, NoLabels []string
 	// Pre-computed: true if label filters have duplicate keys (needs grouping)
 	LabelFiltersHaveDuplicates bool
 	// Pre-computed grouped label filters (only populated if duplicates exist)
@@ -67,10 +105,19 @@ type Matcher struct {
 	// Annotation filters (reuse LabelFilter type)
 	AnnotationFilters  []LabelFilter
 	AnnotationKeyRegex []*regexp.Regexp // Pre-compiled regexes
+	HasAnnotations     []string
+	NoAnnotations      []string
 	// Pre-computed: true if annotation filters have duplicate keys (needs grouping)
 	AnnotationFiltersHaveDuplicates bool
 	// Pre-computed grouped annotation filters (only populated if duplicates exist)
 	AnnotationFiltersByKey map[string][]LabelFilter
+
+	// Owner filters: kinds (case-insensitive, any of) and name globs (any of)
+	OwnerKinds []string
+	OwnerNames []string
+
+	// Invert keeps names that do NOT match the includes (see InvertedMatches)
+	Invert bool
 }
 
 type LabelMode int
@@ -80,6 +127,17 @@ const (
 	LabelPrefix
 	LabelContains
 	LabelRegex
+	// LabelNumeric compares the value as an integer; Pattern is a compareIntExpr
+	// expression such as ">5"
+	LabelNumeric
+	// LabelIn/LabelNotIn test membership in Values. Like a Kubernetes notin
+	// selector, LabelNotIn also matches objects without the key.
+	LabelIn
+	LabelNotIn
+	// LabelAbsent requires the key to be missing (--label '!key'); LabelNotGlob
+	// requires it present with a value not matching Pattern (--label 'key!=glob')
+	LabelAbsent
+	LabelNotGlob
 )
 
 type LabelFilter struct {
@@ -87,6 +145,10 @@ type LabelFilter struct {
 	Pattern       string
 	Mode          LabelMode
 	CompiledRegex *regexp.Regexp // Pre-compiled regex for LabelRegex mode (nil if not regex mode)
+	// IgnoreCase folds values before comparing; Pattern is expected to be lowercased already
+	IgnoreCase bool
+	// Values is the set for LabelIn/LabelNotIn
+	Values []string
 }
 
 func parseLabelKV(kv string, mode LabelMode) (LabelFilter, error) {
@@ -97,7 +159,90 @@ func parseLabelKV(kv string, mode LabelMode) (LabelFilter, error) {
 	return LabelFilter{Key: parts[0], Pattern: parts[1], Mode: mode}, nil
 }
 
+// parseLabelSelectorKV parses a --label filter: key=glob, plus the kubectl-style
+// negations !key and key!=glob.
+func parseLabelSelectorKV(kv string) (LabelFilter, error) {
+	if key, ok := strings.CutPrefix(kv, "!"); ok {
+		if key == "" || strings.Contains(key, "=") {
+			return LabelFilter{}, fmt.Errorf("label filter !key takes no value: %s", kv)
+		}
+		return LabelFilter{Key: key, Mode: LabelAbsent}, nil
+	}
+	if key, pattern, ok := strings.Cut(kv, "!="); ok {
+		if key == "" {
+			return LabelFilter{}, fmt.Errorf("label filter requires key!=value: %s", kv)
+		}
+		return LabelFilter{Key: key, Pattern: pattern, Mode: LabelNotGlob}, nil
+	}
+	return parseLabelKV(kv, LabelGlob)
+}
+
+// matchesMissingKey reports whether a filter in mode is satisfied by an object
+// without its key.
+func matchesMissingKey(mode LabelMode) bool {
+	return mode == LabelNotIn || mode == LabelAbsent
+}
+
+// parseLabelSetKV parses key=a,b,c for LabelIn/LabelNotIn.
+func parseLabelSetKV(kv string, mode LabelMode) (LabelFilter, error) {
+	lf, err := parseLabelKV(kv, mode)
+	if err != nil {
+		return lf, err
+	}
+	lf.Values = splitCommaList(lf.Pattern)
+	if len(lf.Values) == 0 {
+		return lf, fmt.Errorf("label set filter requires key=a,b,...: %s", kv)
+	}
+	return lf, nil
+}
+
+// numericComparisons maps the --label-*/--annotation-* comparison suffixes to
+// compareIntExpr operators.
+var numericComparisons = map[string]string{"gt": ">", "ge": ">=", "lt": "<", "le": "<=", "eq": "="}
+
+// parseNumericLabelKV parses key=N for a numeric comparison filter like --label-gt.
+func parseNumericLabelKV(kv, cmp string) (LabelFilter, error) {
+	lf, err := parseLabelKV(kv, LabelNumeric)
+	if err != nil {
+		return lf, err
+	}
+	if n, err := strconv.Atoi(lf.Pattern); err != nil || n < 0 {
+		return lf, fmt.Errorf("numeric comparison requires key=N with a non-negative integer N: %s", kv)
+	}
+	lf.Pattern = numericComparisons[cmp] + lf.Pattern
+	return lf, nil
+}
+
+// prepareLabelFilter compiles regex filters and, with --ignore-case, folds the
+// pattern using Unicode lowercasing so values like "GRÜN" match "grün".
+func prepareLabelFilter(lf LabelFilter, ignoreCase bool) LabelFilter {
+	if ignoreCase {
+		lf.IgnoreCase = true
+		if lf.Mode != LabelRegex {
+			lf.Pattern = strings.ToLower(lf.Pattern)
+		}
+		if len(lf.Values) > 0 {
+			folded := make([]string, len(lf.Values))
+			for i, v := range lf.Values {
+				folded[i] = strings.ToLower(v)
+			}
+			lf.Values = folded
+		}
+	}
+	if lf.Mode == LabelRegex {
+		if ignoreCase {
+			lf.CompiledRegex = regexp.MustCompile("(?i)" + lf.Pattern)
+		} else {
+			lf.CompiledRegex = regexp.MustCompile(lf.Pattern)
+		}
+	}
+	return lf
+}
+
 func labelValueMatches(value string, lf LabelFilter) bool {
+	if lf.IgnoreCase && lf.Mode != LabelRegex {
+		value = toLowerFast(value)
+	}
 	switch lf.Mode {
 	case LabelGlob:
 		ok, _ := path.Match(lf.Pattern, value)
@@ -106,6 +251,23 @@ func labelValueMatches(value string, lf LabelFilter) bool {
 		return strings.HasPrefix(value, lf.Pattern)
 	case LabelContains:
 		return strings.Contains(value, lf.Pattern)
+	case LabelNumeric:
+		n, err := strconv.Atoi(value)
+		return err == nil && compareIntExpr(n, lf.Pattern)
+	case LabelAbsent:
+		return false
+	case LabelNotGlob:
+		ok, _ := path.Match(lf.Pattern, value)
+		return !ok
+	case LabelIn, LabelNotIn:
+		in := false
+		for _, v := range lf.Values {
+			if v == value {
+				in = true
+				break
+			}
+		}
+		return in == (lf.Mode == LabelIn)
 	case LabelRegex:
 		if lf.CompiledRegex != nil {
 			return lf.CompiledRegex.MatchString(value)
@@ -119,14 +281,32 @@ func labelValueMatches(value string, lf LabelFilter) bool {
 	}
 }
 
-// LabelsAllowed applies AND across different keys, and OR across multiple filters of the same key.
-func (m Matcher) LabelsAllowed(labels map[string]string) bool {
-	// Accuracy: handle nil maps gracefully
-	if labels == nil {
-		// If filters require labels, nil means no match
-		if len(m.LabelFilters) > 0 || len(m.LabelKeyRegex) > 0 {
+// keysPresent reports whether every has key is in m and no key of not is.
+// A nil map has no keys.
+func keysPresent(m map[string]string, has, not []string) bool {
+	for _, k := range has {
+		if _, ok := m[k]; !ok {
 			return false
 		}
+	}
+	for _, k := range not {
+		if _, ok := m[k]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// LabelsAllowed applies the label value filters, then --has-label/--no-label.
+func (m Matcher) LabelsAllowed(labels map[string]string) bool {
+	return m.labelValuesAllowed(labels) && keysPresent(labels, m.HasLabels, m.NoLabels)
+}
+
+// labelValuesAllowed applies AND across different keys, and OR across multiple filters of the same key.
+// A missing key fails every filter except --label-notin and --label '!key'.
+func (m Matcher) labelValuesAllowed(labels map[string]string) bool {
+	// A nil map reads as empty, so it only needs the filters below when there are any
+	if labels == nil && len(m.LabelFilters) == 0 && len(m.LabelKeyRegex) == 0 {
 		return true
 	}
 	if len(m.LabelFilters) == 0 {
@@ -139,6 +319,9 @@ func (m Matcher) LabelsAllowed(labels map[string]string) bool {
 	if !m.LabelFiltersHaveDuplicates {
 		for _, lf := range m.LabelFilters {
 			val, ok := labels[lf.Key]
+			if !ok && matchesMissingKey(lf.Mode) {
+				continue
+			}
 			if !ok || !labelValueMatches(val, lf) {
 				return false
 			}
@@ -147,11 +330,15 @@ func (m Matcher) LabelsAllowed(labels map[string]string) bool {
 		// Slow path: use pre-computed grouped filters
 		for key, fls := range m.LabelFiltersByKey {
 			val, ok := labels[key]
-			if !ok {
-				return false
-			}
 			matchedAny := false
 			for _, f := range fls {
+				if !ok {
+					if matchesMissingKey(f.Mode) {
+						matchedAny = true
+						break
+					}
+					continue
+				}
 				if labelValueMatches(val, f) {
 					matchedAny = true
 					break
@@ -194,9 +381,14 @@ func (m Matcher) LabelsAllowed(labels map[string]string) bool {
 	return true
 }
 
-// AnnotationsAllowed applies AND across different keys, and OR across multiple filters of the same key.
-// Same logic as LabelsAllowed but for annotations.
+// AnnotationsAllowed applies the annotation value filters, then --has-annotation/--no-annotation.
 func (m Matcher) AnnotationsAllowed(annotations map[string]string) bool {
+	return m.annotationValuesAllowed(annotations) && keysPresent(annotations, m.HasAnnotations, m.NoAnnotations)
+}
+
+// annotationValuesAllowed applies AND across different keys, and OR across multiple filters of the same key.
+// Same logic as labelValuesAllowed but for annotations.
+func (m Matcher) annotationValuesAllowed(annotations map[string]string) bool {
 	// Accuracy: handle nil maps gracefully
 	if annotations == nil {
 		// If filters require annotations, nil means no match
@@ -270,52 +462,111 @@ func (m Matcher) AnnotationsAllowed(annotations map[string]string) bool {
 	return true
 }
 
+// OwnersAllowed checks owner references given as Kind/Name strings. An object passes
+// when at least one owner matches both the kind and the name filters (OR across
+// owners, AND across --owner-kind and --owner-name).
+func (m Matcher) OwnersAllowed(owners []string) bool {
+	if len(m.OwnerKinds) == 0 && len(m.OwnerNames) == 0 {
+		return true
+	}
+	for _, o := range owners {
+		kind, name, _ := strings.Cut(o, "/")
+		if len(m.OwnerKinds) > 0 {
+			kindOK := false
+			for _, k := range m.OwnerKinds {
+				if strings.EqualFold(k, kind) {
+					kindOK = true
+					break
+				}
+			}
+			if !kindOK {
+				continue
+			}
+		}
+		if len(m.OwnerNames) > 0 && !anyGlobMatch([]string{name}, m.OwnerNames) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
 func (m Matcher) Matches(name string) bool {
 	n := name
 	if m.IgnoreCase {
 		// Fast path: check if already lowercase to avoid allocation
 		n = toLowerFast(n)
 	}
+	return m.includesMatch(n) && !m.excluded(n)
+}
 
-	// includes
-	if len(m.Includes) > 0 {
-		matched := false
-		for i, inc := range m.Includes {
-			if m.Mode == MatchRegex && len(m.IncludeRegexes) > i && m.IncludeRegexes[i] != nil {
-				// Use pre-compiled regex
-				if m.IncludeRegexes[i].MatchString(n) {
-					matched = true
-					break
-				}
-			} else {
-				if matchSingleWithDistance(m.Mode, m.IgnoreCase, n, inc, m.FuzzyMaxDistance) {
-					matched = true
-					break
-				}
-			}
+// InvertedMatches is Matches for --invert: names matching none of the include
+// patterns are kept, and excludes still drop names. fullName, when non-empty, is
+// the namespace/name form also tried under -A.
+func (m Matcher) InvertedMatches(name, fullName string) bool {
+	for _, s := range []string{name, fullName} {
+		if s == "" {
+			continue
+		}
+		if m.IgnoreCase {
+			s = toLowerFast(s)
 		}
-		if !matched {
+		if m.includesMatch(s) || m.excluded(s) {
 			return false
 		}
 	}
+	return true
+}
+
+// includesMatch reports whether n (already lowercased under IgnoreCase) matches any
+// include pattern; no includes match everything.
+func (m Matcher) includesMatch(n string) bool {
+	if len(m.Includes) == 0 {
+		return true
+	}
+	for i, inc := range m.Includes {
+		if m.Mode == MatchRegex && len(m.IncludeRegexes) > i && m.IncludeRegexes[i] != nil {
+			// Use pre-compiled regex
+			if m.IncludeRegexes[i].MatchString(n) {
+				return true
+			}
+		} else {
+			if matchSingleWithDistance(m.Mode, m.IgnoreCase, n, inc, m.FuzzyMaxDistance) {
+				return true
+			}
+		}
+	}
+	return false
+}
 
-	// excludes
+// excluded reports whether n matches any exclude pattern.
+func (m Matcher) excluded(n string) bool {
 	for i, exc := range m.Excludes {
 		if m.Mode == MatchRegex && len(m.ExcludeRegexes) > i && m.ExcludeRegexes[i] != nil {
 			// Use pre-compiled regex
 			if m.ExcludeRegexes[i].MatchString(n) {
-				return false
+				return true
 			}
 		} else {
 			if matchSingleWithDistance(m.Mode, m.IgnoreCase, n, exc, m.FuzzyMaxDistance) {
-				return false
+				return true
 			}
 		}
 	}
-	return true
+	return false
 }
 
 func (m Matcher) NamespaceAllowed(ns string) bool {
+	for _, e := range m.NsExclude {
+		if ns == e {
+			return false
+		}
+	}
+	for _, p := range m.NsExcludePrefix {
+		if strings.HasPrefix(ns, p) {
+			return false
+		}
+	}
 	// if no filters, allow all
 	if len(m.NsExact) == 0 && len(m.NsPrefix) == 0 && len(m.NsRegex) == 0 {
 		return true
@@ -355,8 +606,7 @@ func matchSingle(mode MatchMode, ignoreCase bool, target string, pattern string)
 	}
 	switch mode {
 	case MatchGlob:
-		ok, _ := path.Match(p, target)
-		return ok
+		return globMatch(p, target)
 	case MatchRegex:
 		// Note: This function is now only called when regexes aren't pre-compiled
 		// (e.g., for fuzzy mode or when pre-compilation wasn't done)
@@ -371,10 +621,28 @@ func matchSingle(mode MatchMode, ignoreCase bool, target string, pattern string)
 		return strings.Contains(target, p)
 	case MatchFuzzy:
 		return fuzzyContains(target, pattern, 1, ignoreCase)
+	case MatchExact:
+		// Literal comparison: no glob/regex metacharacters are interpreted
+		if ignoreCase {
+			return toLowerFast(target) == p
+		}
+		return target == p
 	default:
-		ok, _ := path.Match(p, target)
+		return globMatch(p, target)
+	}
+}
+
+// globMatch is path.Match with '/' treated as an ordinary character, so that
+// '*' and '?' also span the separator of namespace/name targets under -A.
+func globMatch(pattern, name string) bool {
+	if strings.IndexByte(name, '/') < 0 && strings.IndexByte(pattern, '/') < 0 {
+		ok, _ := path.Match(pattern, name)
 		return ok
 	}
+	// NUL can't occur in object names, so swapping it in for '/' on both sides
+	// keeps literal slashes literal while path.Match no longer stops at them.
+	ok, _ := path.Match(strings.ReplaceAll(pattern, "/", "\x00"), strings.ReplaceAll(name, "/", "\x00"))
+	return ok
 }
 
 func matchSingleWithDistance(mode MatchMode, ignoreCase bool, target string, pattern string, dist int) bool {
@@ -398,17 +666,18 @@ func fuzzyContains(target string, pattern string, dist int, ignoreCase bool) boo
 	if target == "" {
 		return false
 	}
-	t := target
-	p := pattern
 	if ignoreCase {
-		t = strings.ToLower(t)
-		p = strings.ToLower(p)
+		target = toLowerFast(target)
+		pattern = toLowerFast(pattern)
 	}
+	// Distances are counted in runes so a multibyte character is a single edit
+	t := []rune(target)
+	p := []rune(pattern)
 	if levenshteinBounded(t, p, dist) <= dist {
 		return true
 	}
 	// Token-based checks using manual iteration to avoid allocations
-	// We find token boundaries and check levenshtein on substrings directly
+	// We find token boundaries and check levenshtein on subslices directly
 	tLen := len(t)
 	tokenStart := 0
 	cumulativeEnd := 0
@@ -454,10 +723,7 @@ func fuzzyContains(target string, pattern string, dist int, ignoreCase bool) boo
 
 // levenshteinBounded computes edit distance but exits early if it exceeds maxDist.
 // Returns the actual distance if <= maxDist, otherwise returns maxDist+1.
-func levenshteinBounded(a, b string, maxDist int) int {
-	if a == b {
-		return 0
-	}
+func levenshteinBounded(a, b []rune, maxDist int) int {
 	la, lb := len(a), len(b)
 	if la == 0 {
 		return lb
@@ -473,7 +739,7 @@ func levenshteinBounded(a, b string, maxDist int) int {
 	if diff > maxDist {
 		return maxDist + 1
 	}
-	// Get pooled rows to avoid allocations
+	// Get pooled rows (sized by rune count) to avoid allocations
 	rows := levenshteinPool.Get().(*levenshteinRows)
 	needed := lb + 1
 	if cap(rows.prev) < needed {
@@ -516,10 +782,7 @@ func levenshteinBounded(a, b string, maxDist int) int {
 	return result
 }
 
-func levenshtein(a, b string) int {
-	if a == b {
-		return 0
-	}
+func levenshtein(a, b []rune) int {
 	la, lb := len(a), len(b)
 	if la == 0 {
 		return lb
@@ -579,10 +842,11 @@ func min3(a, b, c int) int {
 // This is a fast path optimization for the common case where pod/resource names
 // are already lowercase (Kubernetes naming conventions).
 func toLowerFast(s string) string {
-	// Quick scan: if all bytes are already lowercase (or non-alpha), return as-is
+	// Quick scan: if all bytes are already lowercase ASCII (or non-alpha), return as-is.
+	// Multibyte runes may be uppercase (e.g. "Ü"), so they take the Unicode-aware path.
 	for i := 0; i < len(s); i++ {
 		c := s[i]
-		if c >= 'A' && c <= 'Z' {
+		if (c >= 'A' && c <= 'Z') || c >= utf8.RuneSelf {
 			// Found uppercase, need to convert
 			return strings.ToLower(s)
 		}
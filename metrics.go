@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// printMetrics writes Prometheus textfile-collector lines counting matched objects
+// per namespace and phase, e.g.:
+//
+//	kube_wild_matched{resource="pods",namespace="prod",phase="Running"} 12
+func printMetrics(w io.Writer, opts CLIOptions, matched []matchedRef) {
+	type key struct{ ns, phase string }
+	counts := map[key]int{}
+	for _, m := range matched {
+		counts[key{m.ns, m.phase}]++
+	}
+	keys := make([]key, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].ns != keys[j].ns {
+			return keys[i].ns < keys[j].ns
+		}
+		return keys[i].phase < keys[j].phase
+	})
+	if !opts.Bare {
+		fmt.Fprintf(w, "# kube_wild_format_version %s\n", wildFormatVersion)
+	}
+	fmt.Fprintln(w, "# HELP kube_wild_matched Number of objects matched by kubectl-wild filters.")
+	fmt.Fprintln(w, "# TYPE kube_wild_matched gauge")
+	for _, k := range keys {
+		fmt.Fprintf(w, "kube_wild_matched{resource=\"%s\",namespace=\"%s\",phase=\"%s\"} %d\n",
+			escapeMetricLabel(displayResource(opts.Resource)), escapeMetricLabel(k.ns), escapeMetricLabel(k.phase), counts[k])
+	}
+}
+
+// escapeMetricLabel escapes a label value per the Prometheus text exposition format.
+func escapeMetricLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// wildFormatVersion is bumped whenever structured output (--json, --metrics) changes
+// incompatibly, so scripts can detect format changes. --bare drops the header.
+const wildFormatVersion = "1"
+
+// jsonItem is one --json item. Fields are only ever added, so adding one doesn't
+// bump wildFormatVersion; empty fields are omitted.
+type jsonItem struct {
+	Namespace string            `json:"namespace,omitempty"`
+	Name      string            `json:"name"`
+	Phase     string            `json:"phase,omitempty"`
+	Ready     string            `json:"ready,omitempty"`
+	Restarts  *int              `json:"restarts,omitempty"` // pods only, so 0 is kept
+	Node      string            `json:"node,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Reasons   []string          `json:"reasons,omitempty"` // container/pod reasons beyond the phase
+	Owners    []string          `json:"owners,omitempty"`  // Kind/Name of ownerReferences
+}
+
+// newJSONItem fills a jsonItem from discovery; restarts and reasons only apply
+// when container statuses are known.
+func newJSONItem(m matchedRef) jsonItem {
+	item := jsonItem{Namespace: m.ns, Name: m.name, Phase: m.phase, Ready: readyColumn(m), Node: m.node, Labels: m.labels, Owners: m.owners}
+	if m.containers > 0 {
+		restarts := m.restarts
+		item.Restarts = &restarts
+	}
+	seen := map[string]bool{m.phase: true, "Running": true}
+	for _, r := range m.reasons {
+		if !seen[r] {
+			seen[r] = true
+			item.Reasons = append(item.Reasons, r)
+		}
+	}
+	return item
+}
+
+// readyColumn renders kubectl's READY column ("2/3"), or "" when no container
+// statuses are known.
+func readyColumn(m matchedRef) string {
+	if m.containers == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", m.containers-m.notReady, m.containers)
+}
+
+// printClientTable renders kubectl's `get pods -o wide` columns from the discovered
+// fields, so only filtered rows are printed and kubectl isn't called a second time.
+// Rows are ordered by namespace/name, or by the sortBy column if given.
+func printClientTable(w io.Writer, matched []matchedRef, sortBy string, reverse bool, now time.Time, headers bool) error {
+	rows := append([]matchedRef(nil), matched...)
+	sortMatched(rows, sortBy, reverse)
+	// Same settings as kubectl's table printer
+	tw := tabwriter.NewWriter(w, 6, 4, 3, ' ', 0)
+	if headers {
+		fmt.Fprintln(tw, "NAMESPACE\tNAME\tREADY\tSTATUS\tRESTARTS\tAGE\tIP\tNODE")
+	}
+	for _, m := range rows {
+		age := "<unknown>"
+		if !m.created.IsZero() {
+			age = humanAge(now.Sub(m.created))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d/%d\t%s\t%d\t%s\t%s\t%s\n", m.ns, m.name, m.containers-m.notReady, m.containers,
+			orNone(m.status), m.restarts, age, orNone(m.ip), orNone(m.node))
+	}
+	return tw.Flush()
+}
+
+// sortMatched orders matches ascending by sortBy (age: oldest first; ready: fewest
+// ready containers first), descending with reverse. Ties, and an empty sortBy, fall
+// back to namespace/name so the order is always reproducible.
+func sortMatched(rows []matchedRef, sortBy string, reverse bool) {
+	sort.Slice(rows, func(i, j int) bool {
+		if c := compareMatched(rows[i], rows[j], sortBy); c != 0 {
+			if reverse {
+				return c > 0
+			}
+			return c < 0
+		}
+		if rows[i].ns != rows[j].ns {
+			return rows[i].ns < rows[j].ns
+		}
+		return rows[i].name < rows[j].name
+	})
+}
+
+func compareMatched(a, b matchedRef, sortBy string) int {
+	switch sortBy {
+	case "age":
+		return a.created.Compare(b.created)
+	case "restarts":
+		return cmp.Compare(a.restarts, b.restarts)
+	case "ready":
+		return cmp.Compare(a.containers-a.notReady, b.containers-b.notReady)
+	case "name":
+		return strings.Compare(a.name, b.name)
+	case "node":
+		return strings.Compare(a.node, b.node)
+	case "namespace":
+		return strings.Compare(a.ns, b.ns)
+	}
+	return 0
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+// humanAge formats d like kubectl's AGE column: more precision for young objects,
+// whole days or years for old ones.
+func humanAge(d time.Duration) string {
+	if seconds := int(d.Seconds()); seconds < -1 {
+		return "<invalid>"
+	} else if seconds < 0 {
+		return "0s"
+	} else if seconds < 60*2 {
+		return fmt.Sprintf("%ds", seconds)
+	}
+	minutes := int(d / time.Minute)
+	if minutes < 10 {
+		if s := int(d/time.Second) % 60; s != 0 {
+			return fmt.Sprintf("%dm%ds", minutes, s)
+		}
+		return fmt.Sprintf("%dm", minutes)
+	} else if minutes < 60*3 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	hours := int(d / time.Hour)
+	switch {
+	case hours < 8:
+		if m := minutes % 60; m != 0 {
+			return fmt.Sprintf("%dh%dm", hours, m)
+		}
+		return fmt.Sprintf("%dh", hours)
+	case hours < 48:
+		return fmt.Sprintf("%dh", hours)
+	case hours < 24*8:
+		if h := hours % 24; h != 0 {
+			return fmt.Sprintf("%dd%dh", hours/24, h)
+		}
+		return fmt.Sprintf("%dd", hours/24)
+	case hours < 24*365*2:
+		return fmt.Sprintf("%dd", hours/24)
+	case hours < 24*365*8:
+		if dy := hours / 24 % 365; dy != 0 {
+			return fmt.Sprintf("%dy%dd", hours/24/365, dy)
+		}
+		return fmt.Sprintf("%dy", hours/24/365)
+	}
+	return fmt.Sprintf("%dy", hours/24/365)
+}
+
+// printJSON writes matched objects as {"wildVersion":"1","items":[...]}, or just the
+// items array when bare is set.
+func printJSON(w io.Writer, matched []matchedRef, bare bool) error {
+	items := make([]jsonItem, 0, len(matched))
+	for _, m := range matched {
+		items = append(items, newJSONItem(m))
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if bare {
+		return enc.Encode(items)
+	}
+	return enc.Encode(struct {
+		WildVersion string     `json:"wildVersion"`
+		Items       []jsonItem `json:"items"`
+	}{WildVersion: wildFormatVersion, Items: items})
+}
+
+// printNamesStatus writes one "ns/name<sep>PHASE<sep>restarts" line per match for
+// grep/awk triage. sep defaults to a tab.
+func printNamesStatus(w io.Writer, matched []matchedRef, sep string) {
+	if sep == "" {
+		sep = "\t"
+	}
+	for _, m := range matched {
+		name := m.name
+		if m.ns != "" {
+			name = m.ns + "/" + m.name
+		}
+		fmt.Fprintf(w, "%s%s%s%s%d\n", name, sep, m.phase, sep, m.restarts)
+	}
+}
+
+// printNames writes the matched names for piping into xargs: namespace/name under
+// -A, otherwise the bare name. print0 terminates each entry with NUL instead of a
+// newline.
+func printNames(w io.Writer, matched []matchedRef, allNamespaces, print0 bool) {
+	term := "\n"
+	if print0 {
+		term = "\x00"
+	}
+	for _, m := range matched {
+		name := m.name
+		if allNamespaces && m.ns != "" {
+			name = m.ns + "/" + m.name
+		}
+		fmt.Fprint(w, name, term)
+	}
+}
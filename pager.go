@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+)
+
+const defaultPager = "less -R"
+
+// stdoutIsTerminal reports whether stdout is a TTY; overridable in tests.
+var stdoutIsTerminal = func() bool {
+	fi, err := os.Stdout.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// pagerRunner buffers the stdout of every kubectl invocation so the combined
+// output can be piped through $PAGER once the verb finishes. stderr is not paged.
+type pagerRunner struct {
+	Runner
+	buf bytes.Buffer
+}
+
+func (p *pagerRunner) RunKubectl(args []string) error {
+	stdout, stderr, err := p.CaptureKubectl(args)
+	if len(stderr) > 0 {
+		os.Stderr.Write(stderr)
+	}
+	p.buf.Write(stdout)
+	return err
+}
+
+// page runs $PAGER (default "less -R") on the buffered output. runErr is the
+// verb's own error, which takes precedence over a pager failure.
+func (p *pagerRunner) page(runErr error) error {
+	if p.buf.Len() == 0 {
+		return runErr
+	}
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		pager = defaultPager
+	}
+	cmd := exec.Command("sh", "-c", pager)
+	cmd.Stdin = &p.buf
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil && runErr == nil {
+		return err
+	}
+	return runErr
+}
+
+// usePager reports whether kubectl output should go through the pager: only for
+// interactive get/describe, with --pager set and not overridden by --no-pager.
+func usePager(opts CLIOptions) bool {
+	if !opts.Pager || opts.NoPager {
+		return false
+	}
+	if opts.Verb != VerbGet && opts.Verb != VerbDescribe {
+		return false
+	}
+	return stdoutIsTerminal()
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// serializedRunner captures each kubectl call and writes its output in one piece
+// under a lock, so concurrent calls don't interleave lines on the terminal.
+type serializedRunner struct {
+	Runner
+	mu sync.Mutex
+}
+
+func (s *serializedRunner) RunKubectl(args []string) error {
+	stdout, stderr, err := s.CaptureKubectl(args)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	os.Stdout.Write(stdout)
+	os.Stderr.Write(stderr)
+	return err
+}
+
+// runNamespacesParallel is the -A loop of runVerbInScopes with up to opts.MaxParallel
+// namespaces in flight. Results are merged in namespace order, so the returned error
+// (and the --continue-on-error tally) doesn't depend on which worker finished first.
+// As in the sequential loop, a failed batch stops namespaces that haven't started yet,
+// except for wait and per-name verbs.
+func runNamespacesParallel(runner Runner, verb string, opts CLIOptions, namespaces []string, nsToNames map[string][]string, finalFlags []string, res *batchResult) error {
+	out := &serializedRunner{Runner: runner}
+	errs := make([]error, len(namespaces))
+	results := make([]*batchResult, len(namespaces))
+	var stop atomic.Bool
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(opts.MaxParallel, len(namespaces)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if stop.Load() {
+					continue
+				}
+				ns := namespaces[i]
+				if res != nil {
+					results[i] = &batchResult{}
+				}
+				errs[i] = runBatched(out, verb, opts.Resource, nsToNames[ns], namespaceFlags(ns, finalFlags), opts.ExtraFinal, opts.BatchSize, opts.RetryConflict, false, results[i])
+				if errs[i] != nil && !isPerNameVerb(verb) && verb != "wait" {
+					stop.Store(true)
+				}
+			}
+		}()
+	}
+	for i := range namespaces {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, r := range results {
+		if r != nil {
+			res.succeeded += r.succeeded
+			res.failed += r.failed
+			res.errs = append(res.errs, r.errs...)
+		}
+	}
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -247,7 +247,7 @@ func BenchmarkDiscoverNames_100Items(b *testing.B) {
 	}
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = discoverNames(fr, "pods", nil)
+		_, _ = discoverNames(fr, "pods", nil, false)
 	}
 }
 
@@ -260,6 +260,14 @@ func BenchmarkDiscoverNames_1000Items(b *testing.B) {
 	}
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = discoverNames(fr, "pods", nil)
+		_, _ = discoverNames(fr, "pods", nil, false)
+	}
+}
+
+func BenchmarkDecodeK8sList_1000Items(b *testing.B) {
+	jsonData := generateTestJSON(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = decodeK8sList(strings.NewReader(string(jsonData)), false)
 	}
 }
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const defaultPollTimeout = 5 * time.Minute
+
+// Overridable in tests.
+var (
+	pollInterval = 2 * time.Second
+	pollSleep    = time.Sleep
+)
+
+// runPollUntil repeats discovery and filtering until exactly opts.PollUntilCount
+// resources match, or returns an error once opts.PollTimeout has elapsed.
+// Intended for CI waits such as "until all canary pods are gone".
+func runPollUntil(runner Runner, opts CLIOptions) error {
+	deadline := now().Add(opts.PollTimeout)
+	last := -1
+	for {
+		o := opts
+		matched, err := discoverMatched(runner, &o)
+		if err != nil {
+			return err
+		}
+		if len(matched) == opts.PollUntilCount {
+			fmt.Fprintf(os.Stderr, "%d %s matched given criteria.\n", len(matched), o.Resource)
+			return nil
+		}
+		if len(matched) != last {
+			fmt.Fprintf(os.Stderr, "Waiting: %d %s matched, want %d...\n", len(matched), o.Resource, opts.PollUntilCount)
+			last = len(matched)
+		}
+		remaining := deadline.Sub(now())
+		if remaining <= 0 {
+			return fmt.Errorf("timed out after %s waiting for %d %s to match (last: %d)", opts.PollTimeout, opts.PollUntilCount, o.Resource, len(matched))
+		}
+		if remaining > pollInterval {
+			remaining = pollInterval
+		}
+		pollSleep(remaining)
+	}
+}
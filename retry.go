@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Overridable in tests.
+var (
+	conflictBackoff = 200 * time.Millisecond
+	retrySleep      = time.Sleep
+)
+
+// isConflict reports whether kubectl's stderr describes an optimistic-concurrency
+// conflict (HTTP 409), which is safe to retry against the latest object version.
+func isConflict(stderr []byte) bool {
+	s := string(stderr)
+	return strings.Contains(s, "the object has been modified") ||
+		strings.Contains(s, "(Conflict)") ||
+		strings.Contains(s, "Operation cannot be fulfilled")
+}
+
+// retriesConflicts reports whether --retry-conflict applies to verb's kubectl calls.
+func retriesConflicts(verb string) bool {
+	return verb == "patch" || verb == "label" || verb == "annotate"
+}
+
+// runMutating runs a mutating kubectl call (patch/label/annotate), retrying up to
+// retries times with linear backoff when it fails with a conflict. Other errors are
+// returned immediately. Output is captured to inspect stderr, then passed through.
+func runMutating(runner Runner, args []string, retries int) error {
+	for attempt := 0; ; attempt++ {
+		stdout, stderr, err := runner.CaptureKubectl(args)
+		if err != nil && attempt < retries && isConflict(stderr) {
+			fmt.Fprintf(os.Stderr, "Conflict on %s, retrying (%d/%d)...\n", strings.Join(args, " "), attempt+1, retries)
+			retrySleep(time.Duration(attempt+1) * conflictBackoff)
+			continue
+		}
+		os.Stdout.Write(stdout)
+		os.Stderr.Write(stderr)
+		return err
+	}
+}
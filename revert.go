@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Server-populated metadata that must not be present when recreating an object.
+// ownerReferences are dropped too: the owner UIDs would be dangling after a revert
+// and the garbage collector would delete the restored object right away.
+var revertStripMetadata = []string{
+	"uid", "resourceVersion", "creationTimestamp", "generation", "managedFields",
+	"selfLink", "deletionTimestamp", "deletionGracePeriodSeconds", "ownerReferences",
+}
+
+// writeRevertManifest writes the matched objects (as discovered, see keptRawItems)
+// to path as a Kubernetes List in YAML, so `kubectl apply -f path` can recreate
+// them after delete. Returns the number of objects written.
+func writeRevertManifest(runner Runner, opts CLIOptions, matched []matchedRef, path string) (int, error) {
+	kept, err := keptRawItems(runner, opts, matched)
+	if err != nil {
+		return 0, err
+	}
+	items := make([]interface{}, 0, len(kept))
+	for _, k := range kept {
+		dec := json.NewDecoder(bytes.NewReader(k.raw))
+		dec.UseNumber()
+		var obj map[string]interface{}
+		if err := dec.Decode(&obj); err != nil {
+			continue
+		}
+		meta, _ := obj["metadata"].(map[string]interface{})
+		if meta == nil {
+			continue
+		}
+		for _, k := range revertStripMetadata {
+			delete(meta, k)
+		}
+		delete(obj, "status")
+		items = append(items, obj)
+	}
+	list := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      items,
+	}
+	var b strings.Builder
+	for _, ln := range yamlLines(list) {
+		b.WriteString(ln)
+		b.WriteByte('\n')
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		return 0, err
+	}
+	return len(items), nil
+}
+
+// yamlPlainKey matches map keys that are safe to emit unquoted.
+var yamlPlainKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_./-]*$`)
+
+// yamlLines renders a decoded JSON value as block-style YAML lines. Map keys are
+// sorted (like kubectl) and scalars are emitted as JSON, which is valid YAML and
+// avoids any quoting ambiguity (e.g., "yes", "010", multi-line strings).
+func yamlLines(v interface{}) []string {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if len(t) == 0 {
+			return []string{"{}"}
+		}
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var lines []string
+		for _, k := range keys {
+			key := yamlKey(k)
+			child := yamlLines(t[k])
+			if !yamlIsBlock(t[k]) {
+				lines = append(lines, key+": "+child[0])
+				continue
+			}
+			lines = append(lines, key+":")
+			indent := "  "
+			if _, isList := t[k].([]interface{}); isList {
+				// kubectl style: list items align with the parent key
+				indent = ""
+			}
+			for _, c := range child {
+				lines = append(lines, indent+c)
+			}
+		}
+		return lines
+	case []interface{}:
+		if len(t) == 0 {
+			return []string{"[]"}
+		}
+		var lines []string
+		for _, item := range t {
+			child := yamlLines(item)
+			lines = append(lines, "- "+child[0])
+			for _, c := range child[1:] {
+				lines = append(lines, "  "+c)
+			}
+		}
+		return lines
+	default:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return []string{"null"}
+		}
+		return []string{string(b)}
+	}
+}
+
+// yamlIsBlock reports whether v renders as a multi-line block (non-empty map or list).
+func yamlIsBlock(v interface{}) bool {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return len(t) > 0
+	case []interface{}:
+		return len(t) > 0
+	}
+	return false
+}
+
+func yamlKey(k string) string {
+	switch strings.ToLower(k) {
+	case "y", "yes", "n", "no", "on", "off", "true", "false", "null", "~":
+		b, _ := json.Marshal(k)
+		return string(b)
+	}
+	if yamlPlainKey.MatchString(k) {
+		return k
+	}
+	b, _ := json.Marshal(k)
+	return string(b)
+}
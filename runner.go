@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
@@ -53,6 +54,73 @@ func (ExecRunner) CaptureKubectl(args []string) ([]byte, []byte, error) {
 	return outBuf.Bytes(), errBuf.Bytes(), err
 }
 
+// PipeRunner is implemented by runners that can hand back kubectl's stdout as a
+// reader, so large lists are decoded while they arrive instead of being buffered.
+// Close waits for the command and returns its error (with stderr as the message).
+type PipeRunner interface {
+	PipeKubectl(args []string) (io.ReadCloser, error)
+}
+
+type kubectlPipe struct {
+	io.ReadCloser
+	cmd    *exec.Cmd
+	errBuf *bytes.Buffer
+}
+
+func (p *kubectlPipe) Close() error {
+	p.ReadCloser.Close()
+	if err := p.cmd.Wait(); err != nil {
+		if msg := strings.TrimSpace(p.errBuf.String()); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return nil
+}
+
+func (ExecRunner) PipeKubectl(args []string) (io.ReadCloser, error) {
+	cmd := exec.Command(kubectlBin(), args...)
+	var errBuf bytes.Buffer
+	cmd.Stderr = &errBuf
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &kubectlPipe{ReadCloser: stdout, cmd: cmd, errBuf: &errBuf}, nil
+}
+
+// StreamRunner is implemented by runners that can hand kubectl's stdout to a writer
+// while the command is still running (needed for `logs -f`).
+type StreamRunner interface {
+	StreamKubectl(args []string, stdout io.Writer) error
+}
+
+func (ExecRunner) StreamKubectl(args []string, stdout io.Writer) error {
+	cmd := exec.Command(kubectlBin(), args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// streamKubectl streams when the runner supports it and otherwise falls back to
+// capturing the whole output and writing it at once.
+func streamKubectl(runner Runner, args []string, stdout io.Writer) error {
+	if sr, ok := runner.(StreamRunner); ok {
+		return sr.StreamKubectl(args, stdout)
+	}
+	out, errOut, err := runner.CaptureKubectl(args)
+	if len(errOut) > 0 {
+		os.Stderr.Write(errOut)
+	}
+	if _, werr := stdout.Write(out); werr != nil && err == nil {
+		err = werr
+	}
+	return err
+}
+
 // In-process caches (per run). Safe without locks for single-threaded CLI usage.
 var resourceScopeCache = map[string]bool{}
 var resourceCanonicalCache = map[string]string{}
@@ -63,10 +131,45 @@ func clearResourceCaches() {
 	resourceCanonicalCache = map[string]string{}
 }
 
+// connectionFlagNames are kubectl global flags that select the cluster/identity. They
+// must accompany every kubectl call, including api-resources lookups, or scope and
+// name resolution would be answered by the current context instead.
+var connectionFlagNames = []string{"--context", "--kubeconfig", "--cluster", "--user", "--as", "--as-group"}
+
+func isConnectionFlag(f string) bool {
+	for _, n := range connectionFlagNames {
+		if f == n {
+			return true
+		}
+	}
+	return false
+}
+
+// connectionFlags filters flags down to connection-global flags (both "--flag value"
+// and "--flag=value" forms), e.g. to thread --context into api-resources calls.
+func connectionFlags(flags []string) []string {
+	var out []string
+	for i := 0; i < len(flags); i++ {
+		f := flags[i]
+		if isConnectionFlag(f) {
+			if i+1 < len(flags) {
+				out = append(out, f, flags[i+1])
+				i++
+			}
+			continue
+		}
+		if eq := strings.Index(f, "="); eq > 0 && isConnectionFlag(f[:eq]) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
 // isResourceNamespaced determines if a given resource name (e.g., "pods", "bgppeers" or
 // "bgppeers.metallb.io") is namespaced by consulting `kubectl api-resources`.
 // Returns true if namespaced, false if cluster-scoped. If detection fails, defaults to true.
-func isResourceNamespaced(runner Runner, resource string) (bool, error) {
+// globalFlags (see connectionFlags) are appended to the api-resources calls.
+func isResourceNamespaced(runner Runner, resource string, globalFlags []string) (bool, error) {
 	if v, ok := resourceScopeCache[strings.ToLower(resource)]; ok {
 		return v, nil
 	}
@@ -92,13 +195,13 @@ func isResourceNamespaced(runner Runner, resource string) (bool, error) {
 		return false
 	}
 	// First, check namespaced=true
-	out, _, err := runner.CaptureKubectl([]string{"api-resources", "-o", "name", "--verbs=list", "--namespaced=true"})
+	out, _, err := runner.CaptureKubectl(append([]string{"api-resources", "-o", "name", "--verbs=list", "--namespaced=true"}, globalFlags...))
 	if err == nil && matches(string(out), resource) {
 		resourceScopeCache[strings.ToLower(resource)] = true
 		return true, nil
 	}
 	// Then, check namespaced=false
-	out2, _, err2 := runner.CaptureKubectl([]string{"api-resources", "-o", "name", "--verbs=list", "--namespaced=false"})
+	out2, _, err2 := runner.CaptureKubectl(append([]string{"api-resources", "-o", "name", "--verbs=list", "--namespaced=false"}, globalFlags...))
 	if err2 == nil && matches(string(out2), resource) {
 		resourceScopeCache[strings.ToLower(resource)] = false
 		return false, nil
@@ -119,14 +222,14 @@ func isResourceNamespaced(runner Runner, resource string) (bool, error) {
 // resolveCanonicalResource resolves user-provided resource tokens (shortname, singular, plural,
 // or group-qualified) to canonical form as printed by `kubectl api-resources -o name`, e.g.,
 // "bgppeers.metallb.io". If resolution fails, returns the input unchanged.
-func resolveCanonicalResource(runner Runner, resource string) (string, error) {
+func resolveCanonicalResource(runner Runner, resource string, globalFlags []string) (string, error) {
 	lower := strings.ToLower(resource)
 	if v, ok := resourceCanonicalCache[lower]; ok {
 		return v, nil
 	}
 	// If already contains a dot, verify via -o name list and accept as-is if present
 	if strings.Contains(lower, ".") {
-		if out, _, err := runner.CaptureKubectl([]string{"api-resources", "-o", "name", "--verbs=list"}); err == nil {
+		if out, _, err := runner.CaptureKubectl(append([]string{"api-resources", "-o", "name", "--verbs=list"}, globalFlags...)); err == nil {
 			lines := strings.Split(strings.ToLower(string(out)), "\n")
 			for _, l := range lines {
 				if strings.TrimSpace(l) == lower {
@@ -138,7 +241,7 @@ func resolveCanonicalResource(runner Runner, resource string) (string, error) {
 		// fall through to attempt table-based matching
 	}
 	// Build index from table: NAME, SHORTNAMES, APIGROUP, NAMESPACED, KIND, VERBS
-	out, _, err := runner.CaptureKubectl([]string{"api-resources", "--verbs=list"})
+	out, _, err := runner.CaptureKubectl(append([]string{"api-resources", "--verbs=list"}, globalFlags...))
 	if err != nil {
 		// best-effort: return unchanged
 		resourceCanonicalCache[lower] = lower
@@ -275,47 +378,160 @@ type K8sListPartial struct {
 
 // K8sItemPartial is a single item for streaming JSON parsing
 type K8sItemPartial struct {
+	Kind     string `json:"kind"`
 	Metadata struct {
 		Name              string            `json:"name"`
 		Namespace         string            `json:"namespace"`
 		CreationTimestamp string            `json:"creationTimestamp"`
+		DeletionTimestamp string            `json:"deletionTimestamp"`
 		Labels            map[string]string `json:"labels"`
 		Annotations       map[string]string `json:"annotations"`
 		OwnerReferences   []struct {
 			Kind string `json:"kind"`
 			Name string `json:"name"`
 		} `json:"ownerReferences"`
+		Finalizers    []string `json:"finalizers"`
+		ManagedFields []struct {
+			Manager string `json:"manager"`
+		} `json:"managedFields"`
 	} `json:"metadata"`
 	Spec *struct {
-		NodeName string `json:"nodeName"`
+		NodeName      string `json:"nodeName"`
+		RestartPolicy string `json:"restartPolicy"`
+		// nil means the API default of 30s
+		TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds"`
+		SchedulingGates               []struct {
+			Name string `json:"name"`
+		} `json:"schedulingGates"`
+		NodeSelector map[string]string `json:"nodeSelector"`
+		Tolerations  []struct {
+			Key      string `json:"key"`
+			Operator string `json:"operator"`
+		} `json:"tolerations"`
+		Volumes []struct {
+			PersistentVolumeClaim *struct {
+				ClaimName string `json:"claimName"`
+			} `json:"persistentVolumeClaim"`
+			ConfigMap *nameRefPartial `json:"configMap"`
+			Secret    *struct {
+				SecretName string `json:"secretName"`
+			} `json:"secret"`
+			Projected *struct {
+				Sources []struct {
+					ConfigMap *nameRefPartial `json:"configMap"`
+					Secret    *nameRefPartial `json:"secret"`
+				} `json:"sources"`
+			} `json:"projected"`
+		} `json:"volumes"`
+		Containers     []containerRefsPartial `json:"containers"`
+		InitContainers []containerRefsPartial `json:"initContainers"`
+		// Only the image is read from ephemeral (debug) containers
+		EphemeralContainers []containerRefsPartial `json:"ephemeralContainers"`
+		ImagePullSecrets    []nameRefPartial       `json:"imagePullSecrets"`
+		Replicas            *int                   `json:"replicas"` // nil means the default of 1
+
+		// Only presence matters; kept raw to avoid decoding the rule tree
+		Affinity map[string]json.RawMessage `json:"affinity"`
 	} `json:"spec"`
 	Status *struct {
-		Phase             string `json:"phase"`
-		ContainerStatuses []struct {
-			Name         string `json:"name"`
-			Ready        bool   `json:"ready"`
-			RestartCount int    `json:"restartCount"`
-			State        *struct {
-				Waiting *struct {
-					Reason string `json:"reason"`
-				} `json:"waiting"`
-				Terminated *struct {
-					Reason string `json:"reason"`
-				} `json:"terminated"`
-				Running *struct{} `json:"running"`
-			} `json:"state"`
-		} `json:"containerStatuses"`
+		Phase                 string                   `json:"phase"`
+		Reason                string                   `json:"reason"` // pod-level, e.g. Evicted
+		PodIP                 string                   `json:"podIP"`
+		HostIP                string                   `json:"hostIP"`
+		QOSClass              string                   `json:"qosClass"`
+		Replicas              int                      `json:"replicas"`
+		ReadyReplicas         int                      `json:"readyReplicas"`
+		ContainerStatuses     []containerStatusPartial `json:"containerStatuses"`
+		InitContainerStatuses []containerStatusPartial `json:"initContainerStatuses"`
+		Conditions            []struct {
+			Type               string `json:"type"`
+			Status             string `json:"status"`
+			LastTransitionTime string `json:"lastTransitionTime"`
+		} `json:"conditions"`
 	} `json:"status"`
 }
 
-func discoverNames(runner Runner, resource string, discoveryFlags []string) ([]NameRef, error) {
+// nameRefPartial is a reference to another object by name (configMapRef, secretKeyRef, ...)
+type nameRefPartial struct {
+	Name string `json:"name"`
+}
+
+// containerRefsPartial holds the image and ConfigMap/Secret references of a (init) container
+type containerRefsPartial struct {
+	Image   string `json:"image"`
+	EnvFrom []struct {
+		ConfigMapRef *nameRefPartial `json:"configMapRef"`
+		SecretRef    *nameRefPartial `json:"secretRef"`
+	} `json:"envFrom"`
+	Env []struct {
+		ValueFrom *struct {
+			ConfigMapKeyRef *nameRefPartial `json:"configMapKeyRef"`
+			SecretKeyRef    *nameRefPartial `json:"secretKeyRef"`
+		} `json:"valueFrom"`
+	} `json:"env"`
+
+	// Only presence matters; kept raw to avoid decoding the probe handlers
+	ReadinessProbe json.RawMessage `json:"readinessProbe"`
+	LivenessProbe  json.RawMessage `json:"livenessProbe"`
+}
+
+// appendRef appends the referenced name when the reference is set
+func appendRef(names []string, ref *nameRefPartial) []string {
+	if ref != nil && ref.Name != "" {
+		return append(names, ref.Name)
+	}
+	return names
+}
+
+// containerStatusPartial is the subset of a (init) container status used for filtering
+type containerStatusPartial struct {
+	Name         string `json:"name"`
+	Ready        bool   `json:"ready"`
+	RestartCount int    `json:"restartCount"`
+	State        *struct {
+		Waiting *struct {
+			Reason string `json:"reason"`
+		} `json:"waiting"`
+		Terminated *struct {
+			Reason string `json:"reason"`
+		} `json:"terminated"`
+		Running *struct{} `json:"running"`
+	} `json:"state"`
+	LastState *struct {
+		Terminated *struct {
+			FinishedAt string `json:"finishedAt"`
+		} `json:"terminated"`
+	} `json:"lastState"`
+}
+
+// discoveryArgs builds the `kubectl get <resource> -o json ...` call used for discovery.
+func discoveryArgs(runner Runner, resource string, discoveryFlags []string) []string {
 	args := []string{"get", resource, "-o", "json"}
 	// Filter out user-provided output flags and drop -A/-n for cluster-scoped resources
 	filtered := filterOutputFlags(discoveryFlags)
-	if namespaced, err := isResourceNamespaced(runner, resource); err == nil && !namespaced {
+	if namespaced, err := isResourceNamespaced(runner, resource, connectionFlags(discoveryFlags)); err == nil && !namespaced {
 		filtered = stripAllNamespacesFlag(stripNamespaceFlag(filtered))
 	}
-	args = append(args, filtered...)
+	return append(args, filtered...)
+}
+
+// discoverNames lists resource and converts each item to a NameRef. keepRaw retains
+// every item's JSON in NameRef.Raw; when streaming this costs a copy per item, so
+// callers only ask for it when they will render the objects afterwards.
+func discoverNames(runner Runner, resource string, discoveryFlags []string, keepRaw bool) ([]NameRef, error) {
+	args := discoveryArgs(runner, resource, discoveryFlags)
+	if pr, ok := runner.(PipeRunner); ok {
+		rc, err := pr.PipeKubectl(args)
+		if err != nil {
+			return nil, err
+		}
+		refs, decErr := decodeK8sList(rc, keepRaw)
+		// kubectl's own failure explains an unparsable (usually empty) stream better
+		if err := rc.Close(); err != nil {
+			return nil, err
+		}
+		return refs, decErr
+	}
 	out, errOut, err := runner.CaptureKubectl(args)
 	if err != nil {
 		if len(errOut) > 0 {
@@ -328,6 +544,92 @@ func discoverNames(runner Runner, resource string, discoveryFlags []string) ([]N
 	return parseK8sListStreaming(out)
 }
 
+// getPooledItem takes a K8sItemPartial from the pool with fields reset that might
+// have data from previous use.
+func getPooledItem() *K8sItemPartial {
+	it := itemPool.Get().(*K8sItemPartial)
+	it.Kind = ""
+	it.Metadata.Name = ""
+	it.Metadata.Namespace = ""
+	it.Metadata.CreationTimestamp = ""
+	it.Metadata.DeletionTimestamp = ""
+	it.Metadata.Labels = nil
+	it.Metadata.Annotations = nil
+	it.Metadata.OwnerReferences = nil
+	it.Metadata.Finalizers = nil
+	it.Metadata.ManagedFields = nil
+	it.Spec = nil
+	it.Status = nil
+	return it
+}
+
+// decodeK8sList decodes a `kubectl get -o json` list from r token by token, so only
+// one item is held in decoded form at a time.
+func decodeK8sList(r io.Reader, keepRaw bool) ([]NameRef, error) {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err == io.EOF {
+		return nil, fmt.Errorf("empty response from kubectl")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubectl json output: %w", err)
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return nil, fmt.Errorf("no items array found in kubectl output")
+	}
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse kubectl json output: %w", err)
+		}
+		if key, _ := keyTok.(string); key != "items" {
+			// apiVersion, kind, metadata: small, skip without decoding
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil, fmt.Errorf("failed to parse kubectl json output: %w", err)
+			}
+			continue
+		}
+		return decodeK8sItems(dec, keepRaw)
+	}
+	return nil, fmt.Errorf("no items array found in kubectl output")
+}
+
+func decodeK8sItems(dec *json.Decoder, keepRaw bool) ([]NameRef, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse items array: %w", err)
+	}
+	if tok == nil {
+		return []NameRef{}, nil // "items": null
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return nil, fmt.Errorf("failed to parse items array: unexpected %v", tok)
+	}
+	var refs []NameRef
+	for dec.More() {
+		it := getPooledItem()
+		var raw json.RawMessage
+		var err error
+		if keepRaw {
+			if err = dec.Decode(&raw); err == nil {
+				err = json.Unmarshal(raw, it)
+			}
+		} else {
+			err = dec.Decode(it)
+		}
+		if err != nil {
+			itemPool.Put(it)
+			return nil, fmt.Errorf("failed to decode item: %w", err)
+		}
+		ref := processItem(it)
+		ref.Raw = raw
+		refs = append(refs, ref)
+		itemPool.Put(it)
+	}
+	return refs, nil
+}
+
 // parseK8sListStreaming uses a streaming JSON decoder to parse items one at a time.
 // This is more memory-efficient than json.Unmarshal for large lists because it
 // doesn't need to hold the entire parsed structure in memory.
@@ -370,22 +672,18 @@ func parseK8sListStreaming(data []byte) ([]NameRef, error) {
 
 	// Stream through array items using pooled structs
 	for dec.More() {
-		it := itemPool.Get().(*K8sItemPartial)
-		// Reset fields that might have data from previous use
-		it.Metadata.Name = ""
-		it.Metadata.Namespace = ""
-		it.Metadata.CreationTimestamp = ""
-		it.Metadata.Labels = nil
-		it.Metadata.Annotations = nil
-		it.Metadata.OwnerReferences = nil
-		it.Spec = nil
-		it.Status = nil
-
+		it := getPooledItem()
+		start := dec.InputOffset()
 		if err := dec.Decode(it); err != nil {
 			itemPool.Put(it)
 			return nil, fmt.Errorf("failed to decode item: %w", err)
 		}
-		refs = append(refs, processItem(it))
+		ref := processItem(it)
+		// Keep the item's bytes (a subslice of data, no copy) so later steps can
+		// render the matched objects without listing them again.
+		raw := data[arrayStart+int(start) : arrayStart+int(dec.InputOffset())]
+		ref.Raw = json.RawMessage(bytes.TrimLeft(raw, " \t\r\n,"))
+		refs = append(refs, ref)
 		itemPool.Put(it)
 	}
 
@@ -406,22 +704,91 @@ func processItem(it *K8sItemPartial) NameRef {
 	var phase string
 	totalRestarts := 0
 	notReady := 0
+	totalContainers := 0
 	var reasonsByContainer map[string][]string
+	var lastRestart time.Time
+	var readyTransition time.Time
+	var conditions map[string]string
+	var podIP, hostIP, qosClass, initReason, containerReason string
 
 	if it.Status != nil {
+		podIP = it.Status.PodIP
+		hostIP = it.Status.HostIP
+		qosClass = it.Status.QOSClass
+		if len(it.Status.Conditions) > 0 {
+			conditions = make(map[string]string, len(it.Status.Conditions))
+		}
+		for _, c := range it.Status.Conditions {
+			if c.Type != "" {
+				conditions[c.Type] = c.Status
+			}
+			if c.Type != "Ready" || c.LastTransitionTime == "" {
+				continue
+			}
+			if t, err := time.Parse(time.RFC3339, c.LastTransitionTime); err == nil {
+				readyTransition = t
+			}
+		}
 		if it.Status.Phase != "" {
 			phase = it.Status.Phase
 			reasons = append(reasons, it.Status.Phase)
 		}
-		if len(it.Status.ContainerStatuses) > 0 {
-			reasonsByContainer = make(map[string][]string, len(it.Status.ContainerStatuses))
+		if it.Status.Reason != "" {
+			reasons = append(reasons, it.Status.Reason)
+		}
+		totalContainers = len(it.Status.ContainerStatuses)
+		if n := len(it.Status.ContainerStatuses) + len(it.Status.InitContainerStatuses); n > 0 {
+			reasonsByContainer = make(map[string][]string, n)
+		}
+		// Init containers: a crash-looping init container (e.g., a broken migration)
+		// must show up in restarts and reasons. Their reasons are recorded under both
+		// the plain name and "init:<name>" so --container-name can target them
+		// unambiguously. A clean "Completed" exit is normal and not surfaced pod-wide,
+		// and they don't count towards READY.
+		for _, cs := range it.Status.InitContainerStatuses {
+			totalRestarts += cs.RestartCount
+			if cs.LastState != nil && cs.LastState.Terminated != nil && cs.LastState.Terminated.FinishedAt != "" {
+				if t, err := time.Parse(time.RFC3339, cs.LastState.Terminated.FinishedAt); err == nil && t.After(lastRestart) {
+					lastRestart = t
+				}
+			}
+			if cs.State == nil {
+				continue
+			}
+			var reason string
+			if cs.State.Waiting != nil {
+				reason = cs.State.Waiting.Reason
+			} else if cs.State.Terminated != nil {
+				reason = cs.State.Terminated.Reason
+			}
+			if reason == "" {
+				continue
+			}
+			if reason != "Completed" {
+				reasons = append(reasons, reason)
+				if initReason == "" && reason != "PodInitializing" {
+					initReason = reason
+				}
+			}
+			reasonsByContainer[cs.Name] = append(reasonsByContainer[cs.Name], reason)
+			reasonsByContainer["init:"+cs.Name] = append(reasonsByContainer["init:"+cs.Name], reason)
 		}
 		for _, cs := range it.Status.ContainerStatuses {
 			totalRestarts += cs.RestartCount
+			if cs.LastState != nil && cs.LastState.Terminated != nil && cs.LastState.Terminated.FinishedAt != "" {
+				if t, err := time.Parse(time.RFC3339, cs.LastState.Terminated.FinishedAt); err == nil && t.After(lastRestart) {
+					lastRestart = t
+				}
+			}
 			if !cs.Ready {
 				notReady++
 			}
 			if cs.State != nil {
+				if containerReason == "" && cs.State.Waiting != nil {
+					containerReason = cs.State.Waiting.Reason
+				} else if containerReason == "" && cs.State.Terminated != nil {
+					containerReason = cs.State.Terminated.Reason
+				}
 				if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
 					reasons = append(reasons, cs.State.Waiting.Reason)
 					reasonsByContainer[cs.Name] = append(reasonsByContainer[cs.Name], cs.State.Waiting.Reason)
@@ -446,23 +813,161 @@ func processItem(it *K8sItemPartial) NameRef {
 		}
 	}
 
+	var managers []string
+	for _, mf := range it.Metadata.ManagedFields {
+		if mf.Manager != "" {
+			managers = append(managers, mf.Manager)
+		}
+	}
+
+	// Terminating takes precedence over the reported phase (a Running pod can be
+	// terminating), matching what kubectl shows in the STATUS column.
+	var deletedAt time.Time
+	if it.Metadata.DeletionTimestamp != "" {
+		if t, err := time.Parse(time.RFC3339, it.Metadata.DeletionTimestamp); err == nil {
+			deletedAt = t
+			phase = "Terminating"
+			reasons = append(reasons, "Terminating")
+		}
+	}
+
+	// kubectl's STATUS column: container reasons beat the pod reason, which beats
+	// the phase; a failing init container is shown as Init:<reason>.
+	status := phase
+	switch {
+	case !deletedAt.IsZero():
+	case initReason != "":
+		status = "Init:" + initReason
+	case containerReason != "":
+		status = containerReason
+	case it.Status != nil && it.Status.Reason != "":
+		status = it.Status.Reason
+	}
+
 	nodeName := ""
+	restartPolicy := ""
+	gracePeriod := int64(30)
+	var gates []string
+	var nodeSelector map[string]string
+	hasAffinity := false
+	scaledToZero, replicasUnready := false, false
+	missingReadiness, missingLiveness := false, false
+	var tolerations []string
+	var pvcs, configMaps, secrets, images []string
 	if it.Spec != nil {
 		nodeName = it.Spec.NodeName
+		restartPolicy = it.Spec.RestartPolicy
+		if it.Spec.TerminationGracePeriodSeconds != nil {
+			gracePeriod = *it.Spec.TerminationGracePeriodSeconds
+		}
+		nodeSelector = it.Spec.NodeSelector
+		hasAffinity = len(it.Spec.Affinity) > 0
+		scaledToZero = it.Spec.Replicas != nil && *it.Spec.Replicas == 0 && (it.Status == nil || it.Status.Replicas == 0)
+		if it.Spec.Replicas != nil {
+			ready := 0
+			if it.Status != nil {
+				ready = it.Status.ReadyReplicas
+			}
+			replicasUnready = ready < *it.Spec.Replicas
+		}
+		for _, t := range it.Spec.Tolerations {
+			// An empty key with operator Exists tolerates every taint
+			if t.Key == "" && t.Operator == "Exists" {
+				tolerations = append(tolerations, "*")
+				continue
+			}
+			if t.Key != "" {
+				tolerations = append(tolerations, t.Key)
+			}
+		}
+		for _, g := range it.Spec.SchedulingGates {
+			gates = append(gates, g.Name)
+		}
+		for _, v := range it.Spec.Volumes {
+			if v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName != "" {
+				pvcs = append(pvcs, v.PersistentVolumeClaim.ClaimName)
+			}
+			configMaps = appendRef(configMaps, v.ConfigMap)
+			if v.Secret != nil && v.Secret.SecretName != "" {
+				secrets = append(secrets, v.Secret.SecretName)
+			}
+			if v.Projected != nil {
+				for _, src := range v.Projected.Sources {
+					configMaps = appendRef(configMaps, src.ConfigMap)
+					secrets = appendRef(secrets, src.Secret)
+				}
+			}
+		}
+		// Init containers run to completion and don't take probes
+		for _, c := range it.Spec.Containers {
+			missingReadiness = missingReadiness || len(c.ReadinessProbe) == 0
+			missingLiveness = missingLiveness || len(c.LivenessProbe) == 0
+		}
+		for _, cs := range [][]containerRefsPartial{it.Spec.InitContainers, it.Spec.Containers} {
+			for _, c := range cs {
+				for _, ef := range c.EnvFrom {
+					configMaps = appendRef(configMaps, ef.ConfigMapRef)
+					secrets = appendRef(secrets, ef.SecretRef)
+				}
+				for _, e := range c.Env {
+					if e.ValueFrom != nil {
+						configMaps = appendRef(configMaps, e.ValueFrom.ConfigMapKeyRef)
+						secrets = appendRef(secrets, e.ValueFrom.SecretKeyRef)
+					}
+				}
+			}
+		}
+		for _, cs := range [][]containerRefsPartial{it.Spec.InitContainers, it.Spec.Containers, it.Spec.EphemeralContainers} {
+			for _, c := range cs {
+				if c.Image != "" {
+					images = append(images, c.Image)
+				}
+			}
+		}
+		for i := range it.Spec.ImagePullSecrets {
+			secrets = appendRef(secrets, &it.Spec.ImagePullSecrets[i])
+		}
 	}
 
 	return NameRef{
+		Kind:               it.Kind,
 		Namespace:          it.Metadata.Namespace,
 		Name:               it.Metadata.Name,
 		CreatedAt:          created,
 		PodReasons:         reasons,
 		PodPhase:           phase,
+		PodIP:              podIP,
+		HostIP:             hostIP,
+		Status:             status,
 		Labels:             it.Metadata.Labels,
 		Annotations:        it.Metadata.Annotations,
 		NodeName:           nodeName,
 		TotalRestarts:      totalRestarts,
 		NotReadyContainers: notReady,
+		TotalContainers:    totalContainers,
+		ReadyContainers:    totalContainers - notReady,
+		Conditions:         conditions,
+		ReadyTransitionAt:  readyTransition,
+		DeletionTimestamp:  deletedAt,
 		ReasonsByContainer: reasonsByContainer,
 		Owners:             owners,
+		SchedulingGates:    gates,
+		Finalizers:         it.Metadata.Finalizers,
+		NodeSelector:       nodeSelector,
+		HasAffinity:        hasAffinity,
+		ScaledToZero:       scaledToZero,
+		ReplicasUnready:    replicasUnready,
+		MissingReadiness:   missingReadiness,
+		MissingLiveness:    missingLiveness,
+		Tolerations:        tolerations,
+		LastRestartAt:      lastRestart,
+		RestartPolicy:      restartPolicy,
+		QOSClass:           qosClass,
+		GracePeriodSeconds: gracePeriod,
+		Managers:           managers,
+		PVCs:               pvcs,
+		ConfigMaps:         configMaps,
+		Secrets:            secrets,
+		Images:             images,
 	}
 }
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Filter stages of discoverMatched, in the order the loop checks them.
+const (
+	stageNamespace = iota
+	stageName
+	stageLabels
+	stageAnnotations
+	stageMetadata // owners, finalizers, managers, conditions, replica counts, terminating
+	stageAge
+	stageNode // node name, node selector, affinity, tolerations
+	stageSpec // probes, grace period, restart policy, volumes
+	stageStatus
+	numFilterStages
+)
+
+var filterStageNames = [numFilterStages]string{"namespace", "name", "labels", "annotations", "metadata", "age", "node", "spec", "status"}
+
+// filterFunnel counts how many candidates survive each filter stage, for
+// --selectivity. A nil funnel records nothing, so the filter loop can call it
+// unconditionally.
+type filterFunnel struct {
+	in  int
+	out [numFilterStages]int
+	// stages applied to the whole matched set after the loop (--name-collisions, --sample)
+	post []funnelStage
+}
+
+type funnelStage struct {
+	name    string
+	in, out int
+}
+
+func newFilterFunnel(candidates int) *filterFunnel {
+	return &filterFunnel{in: candidates}
+}
+
+// pass records a candidate that survived stage.
+func (f *filterFunnel) pass(stage int) {
+	if f != nil {
+		f.out[stage]++
+	}
+}
+
+// after records a post-loop stage that narrowed in matches down to out.
+func (f *filterFunnel) after(name string, in, out int) {
+	if f != nil {
+		f.post = append(f.post, funnelStage{name: name, in: in, out: out})
+	}
+}
+
+// stages returns every stage with the candidates entering and surviving it.
+func (f *filterFunnel) stages() []funnelStage {
+	stages := make([]funnelStage, 0, numFilterStages+len(f.post))
+	in := f.in
+	for i, name := range filterStageNames {
+		stages = append(stages, funnelStage{name: name, in: in, out: f.out[i]})
+		in = f.out[i]
+	}
+	return append(stages, f.post...)
+}
+
+// print writes the funnel as a STAGE/IN/OUT/DROPPED table.
+func (f *filterFunnel) print(w io.Writer, resource string) {
+	fmt.Fprintf(w, "selectivity (%s):\n", resource)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "  STAGE\tIN\tOUT\tDROPPED")
+	for _, s := range f.stages() {
+		fmt.Fprintf(tw, "  %s\t%d\t%d\t%d\n", s.name, s.in, s.out, s.in-s.out)
+	}
+	tw.Flush()
+}